@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"strings"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+	"github.com/xogas/cowsay-go/internal/unitext"
+)
+
+const (
+	esc      = ansi.Esc
+	sgrReset = "\x1b[0m"
+)
+
+// escapeCode is an escape sequence removed from a line of text, together
+// with the byte offset in the remaining plain text where it stood.
+type escapeCode struct {
+	pos int
+	seq string
+}
+
+// splitEscapes separates the escape sequences in s from the visible text.
+func splitEscapes(s string) (plain string, codes []escapeCode) {
+	if strings.IndexByte(s, esc) < 0 {
+		return s, nil
+	}
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexByte(s, esc)
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		n := ansi.EscapeLen(s[i:])
+		codes = append(codes, escapeCode{pos: b.Len(), seq: s[i : i+n]})
+		s = s[i+n:]
+	}
+	return b.String(), codes
+}
+
+// stringWidth returns the number of terminal columns s occupies. Escape
+// sequences take no space.
+func stringWidth(s string) int {
+	width := 0
+	for len(s) > 0 {
+		i := strings.IndexByte(s, esc)
+		if i < 0 {
+			return width + unitext.Width(s)
+		}
+		width += unitext.Width(s[:i])
+		s = s[i+ansi.EscapeLen(s[i:]):]
+	}
+	return width
+}
+
+// carrySGR makes every line of the balloon stand on its own: attributes
+// still active at the end of a line are reset before the border and set
+// again at the start of the next line. Other escape sequences are passed
+// through as they are.
+func carrySGR(lines []string) []string {
+	var active []string
+	for i, line := range lines {
+		prefix := strings.Join(active, "")
+		for s := line; ; {
+			j := strings.IndexByte(s, esc)
+			if j < 0 {
+				break
+			}
+			n := ansi.EscapeLen(s[j:])
+			if seq := s[j : j+n]; ansi.IsSGR(seq) {
+				if ansi.SGRResets(seq) {
+					active = active[:0]
+				}
+				if strings.Trim(seq[2:len(seq)-1], "0;") != "" {
+					active = append(active, seq)
+				}
+			}
+			s = s[j+n:]
+		}
+		if len(active) > 0 {
+			line += sgrReset
+		}
+		lines[i] = prefix + line
+	}
+	return lines
+}
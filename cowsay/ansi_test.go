@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"testing"
+)
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{name: "plain", s: "hello", want: 5},
+		{name: "sgr", s: "\x1b[1;31mhello\x1b[0m", want: 5},
+		{name: "256 colors", s: "\x1b[38;5;208m你好\x1b[m", want: 4},
+		{name: "cursor movement", s: "a\x1b[2Kb\x1b[10;20H", want: 2},
+		{name: "osc terminated by bel", s: "\x1b]0;title\atext", want: 4},
+		{name: "osc terminated by st", s: "\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\", want: 4},
+		{name: "unterminated osc", s: "ab\x1b]8;;http://x", want: 2},
+		{name: "two-byte escape", s: "\x1b7ab\x1b8", want: 2},
+		{name: "lone escape", s: "ab\x1b", want: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stringWidth(tc.s); got != tc.want {
+				t.Errorf("stringWidth(%q) = %d, want %d", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCarrySGR(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{
+			name:  "no escapes",
+			lines: []string{"a", "b"},
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "reset on the same line",
+			lines: []string{"\x1b[31ma\x1b[0m", "b"},
+			want:  []string{"\x1b[31ma\x1b[0m", "b"},
+		},
+		{
+			name:  "attributes accumulate",
+			lines: []string{"\x1b[1ma\x1b[4mb", "c\x1b[m", "d"},
+			want:  []string{"\x1b[1ma\x1b[4mb\x1b[0m", "\x1b[1m\x1b[4mc\x1b[m", "d"},
+		},
+		{
+			name:  "a leading reset drops earlier attributes",
+			lines: []string{"\x1b[1ma", "\x1b[0;32mb", "c"},
+			want:  []string{"\x1b[1ma\x1b[0m", "\x1b[1m\x1b[0;32mb\x1b[0m", "\x1b[0;32mc\x1b[0m"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := carrySGR(append([]string(nil), tc.lines...))
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("carrySGR(%q)[%d] = %q, want %q", tc.lines, i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
@@ -23,49 +23,228 @@
 package cowsay
 
 import (
-	"bytes"
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"unicode"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+	"github.com/xogas/cowsay-go/internal/hyphen"
+	"github.com/xogas/cowsay-go/internal/unitext"
+)
+
+// WrapMode controls how a message is laid out in the balloon.
+type WrapMode int
+
+const (
+	// WrapLines keeps the line breaks and blank lines of the message and
+	// wraps each line on its own.
+	WrapLines WrapMode = iota
+	// WrapReflow joins the whole message into a single paragraph and wraps
+	// it.
+	WrapReflow
+	// WrapNone does not wrap at all; the balloon is as wide as the longest
+	// line.
+	WrapNone
+)
+
+// LongWordPolicy controls what happens to words wider than the wrap width.
+type LongWordPolicy int
+
+const (
+	// LongWordKeep puts an over-long word on a line of its own and lets the
+	// balloon grow past the wrap width.
+	LongWordKeep LongWordPolicy = iota
+	// LongWordBreak breaks an over-long word at the wrap column.
+	LongWordBreak
+	// LongWordBreakAtSeparators breaks an over-long word after the last URL
+	// or path separator that fits, and at the wrap column otherwise.
+	LongWordBreakAtSeparators
 )
 
-// stringWidth calculates the display width of a string, considering full-width characters.
-func stringWidth(s string) int {
-	width := 0
-	for _, r := range s {
-		if unicode.Is(unicode.Han, r) || r > 0xFF {
-			width += 2 // full-width
-		} else {
-			width += 1 // half-width
+var longWordPolicyNames = []string{
+	LongWordKeep:              "keep",
+	LongWordBreak:             "break",
+	LongWordBreakAtSeparators: "separators",
+}
+
+// String returns the name of the policy.
+func (p LongWordPolicy) String() string {
+	if p < 0 || int(p) >= len(longWordPolicyNames) {
+		return fmt.Sprintf("LongWordPolicy(%d)", int(p))
+	}
+	return longWordPolicyNames[p]
+}
+
+// ParseLongWordPolicy returns the policy with the given name.
+func ParseLongWordPolicy(name string) (LongWordPolicy, error) {
+	for i, n := range longWordPolicyNames {
+		if n == name {
+			return LongWordPolicy(i), nil
 		}
 	}
-	return width
+	return LongWordKeep, fmt.Errorf("unknown long word policy %q", name)
 }
 
-// buildBalloon wraps the message in a speech balloon.
-func buildBalloon(msg string, wrap int) []byte {
-	if wrap <= 0 {
-		wrap = 40
+// LineBreaking selects where a line may be broken when it is wrapped.
+type LineBreaking int
+
+const (
+	// LineBreakUnicode breaks lines at the opportunities found by the
+	// Unicode Line Breaking Algorithm (UAX #14). Besides spaces and hyphens
+	// this allows breaks between ideographs, while keeping closing
+	// punctuation and Japanese small kana off the start of a line.
+	LineBreakUnicode LineBreaking = iota
+	// LineBreakSpaces breaks lines at spaces only.
+	LineBreakSpaces
+)
+
+var lineBreakingNames = []string{
+	LineBreakUnicode: "unicode",
+	LineBreakSpaces:  "spaces",
+}
+
+// String returns the name of the line breaking strategy.
+func (b LineBreaking) String() string {
+	if b < 0 || int(b) >= len(lineBreakingNames) {
+		return fmt.Sprintf("LineBreaking(%d)", int(b))
 	}
-	words := strings.Fields(msg)
-	if len(words) == 0 {
-		return []byte("< >")
+	return lineBreakingNames[b]
+}
+
+// ParseLineBreaking returns the line breaking strategy with the given name.
+func ParseLineBreaking(name string) (LineBreaking, error) {
+	for i, n := range lineBreakingNames {
+		if n == name {
+			return LineBreaking(i), nil
+		}
 	}
+	return LineBreakUnicode, fmt.Errorf("unknown line breaking strategy %q", name)
+}
 
-	var lines []string
-	var cur strings.Builder
-	cur.WriteString(words[0])
-	for _, word := range words[1:] {
-		if stringWidth(cur.String())+1+stringWidth(word) <= wrap {
-			cur.WriteByte(' ')
-			cur.WriteString(word)
-			continue
+// Alignment controls where the text of each line sits in the balloon.
+type Alignment int
+
+const (
+	// AlignLeft aligns lines on the left edge of the balloon.
+	AlignLeft Alignment = iota
+	// AlignCenter centers lines in the balloon.
+	AlignCenter
+	// AlignRight aligns lines on the right edge of the balloon.
+	AlignRight
+	// AlignJustify stretches the spaces between words so that wrapped lines
+	// reach both edges. Lines that end a line of the message, including the
+	// last one, stay aligned on the left.
+	AlignJustify
+)
+
+var alignmentNames = []string{
+	AlignLeft:    "left",
+	AlignCenter:  "center",
+	AlignRight:   "right",
+	AlignJustify: "justify",
+}
+
+// String returns the name of the alignment.
+func (a Alignment) String() string {
+	if a < 0 || int(a) >= len(alignmentNames) {
+		return fmt.Sprintf("Alignment(%d)", int(a))
+	}
+	return alignmentNames[a]
+}
+
+// ParseAlignment returns the alignment with the given name.
+func ParseAlignment(name string) (Alignment, error) {
+	for i, n := range alignmentNames {
+		if n == name {
+			return Alignment(i), nil
+		}
+	}
+	return AlignLeft, fmt.Errorf("unknown alignment %q", name)
+}
+
+// LineFitting selects how the break opportunities of a line are chosen.
+type LineFitting int
+
+const (
+	// FitGreedy fills each line with as many words as fit before moving to
+	// the next one. It is fast but can leave a ragged right edge and a
+	// single short word on the last line.
+	FitGreedy LineFitting = iota
+	// FitOptimal chooses the breaks of each paragraph together so that the
+	// total badness of its lines is as small as possible, in the manner of
+	// Knuth and Plass.
+	FitOptimal
+)
+
+var lineFittingNames = []string{
+	FitGreedy:  "greedy",
+	FitOptimal: "optimal",
+}
+
+// String returns the name of the line fitting algorithm.
+func (f LineFitting) String() string {
+	if f < 0 || int(f) >= len(lineFittingNames) {
+		return fmt.Sprintf("LineFitting(%d)", int(f))
+	}
+	return lineFittingNames[f]
+}
+
+// ParseLineFitting returns the line fitting algorithm with the given name.
+func ParseLineFitting(name string) (LineFitting, error) {
+	for i, n := range lineFittingNames {
+		if n == name {
+			return LineFitting(i), nil
 		}
-		lines = append(lines, cur.String())
-		cur.Reset()
-		cur.WriteString(word)
 	}
-	lines = append(lines, cur.String())
+	return FitGreedy, fmt.Errorf("unknown line fitting %q", name)
+}
+
+// wordSeparators are the characters after which LongWordBreakAtSeparators
+// prefers to break a word.
+const wordSeparators = "/\\?&=#:;,.-_@+~"
+
+// tabWidth is the distance between tab stops when expanding tabs.
+const tabWidth = 8
+
+// balloonOptions controls how buildBalloon lays out a message.
+type balloonOptions struct {
+	wrap       int
+	think      bool
+	style      BalloonStyle
+	mode       WrapMode
+	align      Alignment
+	fitting    LineFitting
+	lineBreak  LineBreaking
+	hyphenator *hyphen.Hyphenator
+	longWords  LongWordPolicy
+}
+
+// buildBalloon wraps the message in a speech balloon, or in a thought
+// balloon when think is set.
+func buildBalloon(msg string, opts balloonOptions) []byte {
+	out := balloonGrid(msg, opts).Bytes()
+	return out[:len(out)-1]
+}
+
+// balloonGrid is buildBalloon split into cells: the frame is border and
+// the rest is text.
+func balloonGrid(msg string, opts balloonOptions) Grid {
+	if opts.wrap <= 0 {
+		opts.wrap = 40
+	}
+	style := &opts.style
+	if *style == (BalloonStyle{}) {
+		*style = balloonStyles[0]
+	}
+	lines, ends := wrapMessage(msg, opts)
+	if len(lines) == 0 {
+		left, right := style.edges(0, 1, opts.think)
+		row := appendCells(nil, left, RegionBorder)
+		row = appendCells(row, " ", RegionText)
+		return Grid{appendCells(row, right, RegionBorder)}
+	}
 
 	// compute max width
 	max := 0
@@ -76,32 +255,435 @@ func buildBalloon(msg string, wrap int) []byte {
 		}
 	}
 
-	var out bytes.Buffer
-	out.WriteByte(' ')
-	out.Write(bytes.Repeat([]byte("_"), max+2))
-	out.WriteByte('\n')
+	grid := Grid{appendCells(nil, style.TopLeft+strings.Repeat(style.Top, max+2)+style.TopRight, RegionBorder)}
 
-	if len(lines) == 1 {
-		line := lines[0]
+	for i, line := range lines {
+		left, right := style.edges(i, len(lines), opts.think)
+		if opts.align == AlignJustify && !ends[i] {
+			line = justify(line, max)
+		}
 		padding := max - stringWidth(line)
-		out.WriteString(fmt.Sprintf("< %s %s>\n", line, strings.Repeat(" ", padding)))
+		before := 0
+		switch opts.align {
+		case AlignCenter:
+			before = padding / 2
+		case AlignRight:
+			before = padding
+		}
+		row := appendCells(nil, left, RegionBorder)
+		row = appendCells(row, fmt.Sprintf(" %s%s%s ", strings.Repeat(" ", before), line, strings.Repeat(" ", padding-before)), RegionText)
+		grid = append(grid, appendCells(row, right, RegionBorder))
+	}
+
+	return append(grid, appendCells(nil, style.BottomLeft+strings.Repeat(style.Bottom, max+2)+style.BottomRight, RegionBorder))
+}
+
+// wrapMessage splits the message into the lines of the balloon. Escape
+// sequences in the message are kept, and SGR attributes are carried over
+// from one line of the balloon to the next. ends[i] reports whether line i
+// ends a line of the message rather than being broken by wrapping.
+func wrapMessage(msg string, opts balloonOptions) (lines []string, ends []bool) {
+	var paragraphs []string
+	if opts.mode == WrapReflow {
+		words := strings.Fields(msg)
+		if len(words) == 0 {
+			return nil, nil
+		}
+		paragraphs = []string{strings.Join(words, " ")}
 	} else {
-		// first
-		first := lines[0]
-		padding := max - stringWidth(first)
-		out.WriteString(fmt.Sprintf("/ %s %s\\\n", first, strings.Repeat(" ", padding)))
-		// middle
-		for _, line := range lines[1 : len(lines)-1] {
-			padding = max - stringWidth(line)
-			out.WriteString(fmt.Sprintf("| %s  %s|\n", line, strings.Repeat(" ", padding)))
-		}
-		// last
-		last := lines[len(lines)-1]
-		padding = max - stringWidth(last)
-		out.WriteString(fmt.Sprintf("\\ %s %s/\n", last, strings.Repeat(" ", padding)))
-	}
-
-	out.WriteByte(' ')
-	out.WriteString(strings.Repeat("-", max+2))
-	return out.Bytes()
+		if strings.TrimSpace(msg) == "" {
+			return nil, nil
+		}
+		msg = strings.ReplaceAll(msg, "\r\n", "\n")
+		paragraphs = strings.Split(msg, "\n")
+	}
+
+	for _, p := range paragraphs {
+		wrapped := wrapStyled(p, opts)
+		lines = append(lines, wrapped...)
+		for i := range wrapped {
+			ends = append(ends, i == len(wrapped)-1)
+		}
+	}
+	return carrySGR(lines), ends
+}
+
+// wrapStyled wraps a single line that may contain escape sequences. The line
+// is wrapped as plain text, and each escape sequence is then put back on the
+// balloon line holding the text it preceded.
+func wrapStyled(line string, opts balloonOptions) []string {
+	plain, codes := splitEscapes(expandTabs(line))
+	plain = strings.TrimRightFunc(plain, unicode.IsSpace)
+
+	spans := []span{{start: 0, end: len(plain)}}
+	if opts.mode != WrapNone && stringWidth(plain) > opts.wrap {
+		spans = wrapLine(plain, opts)
+	}
+
+	lines := make([]string, len(spans))
+	next := 0
+	for i, sp := range spans {
+		last := i == len(spans)-1
+		var b strings.Builder
+		pos := sp.start
+		for ; next < len(codes) && (last || codes[next].pos <= sp.end); next++ {
+			if at := min(codes[next].pos, sp.end); at > pos {
+				b.WriteString(plain[pos:at])
+				pos = at
+			}
+			b.WriteString(codes[next].seq)
+		}
+		b.WriteString(plain[pos:sp.end])
+		if sp.hyphen {
+			b.WriteByte('-')
+		}
+		lines[i] = b.String()
+	}
+	return lines
+}
+
+// span is the byte range line[start:end] of a wrapped line.
+type span struct {
+	start, end int
+	hyphen     bool // the line ends inside a word and needs a hyphen
+}
+
+// wrapLine wraps a single line at the break opportunities chosen by
+// opts.lineBreak and returns the resulting lines as ranges of line. It keeps
+// the indentation and the spacing between words that stay on the same line;
+// only the spaces where the line is broken are dropped.
+func wrapLine(line string, opts balloonOptions) []span {
+	if opts.fitting == FitOptimal {
+		return wrapOptimal(line, opts)
+	}
+
+	var spans []span
+	start, off, width := 0, 0, 0
+	blank := true // the current line holds no more than indentation
+	flush := func() {
+		spans = append(spans, span{start: start, end: start + len(strings.TrimRight(line[start:off], " "))})
+		start, width, blank = off, 0, true
+	}
+	for _, seg := range lineSegments(line, opts.lineBreak) {
+		word := strings.TrimRight(seg.Text, " ")
+		end := off + len(seg.Text)
+		whole, wordStart := word, off
+		var hyphens []int
+		if opts.hyphenator != nil && width+stringWidth(word) > opts.wrap {
+			hyphens = opts.hyphenator.Points(word)
+		}
+		for width+stringWidth(word) > opts.wrap {
+			// fill the line with as much of the word as fits with a hyphen
+			if cut := hyphenCut(whole, hyphens, off-wordStart, opts.wrap-width-1); cut > 0 {
+				spans = append(spans, span{start: start, end: off + cut, hyphen: true})
+				off += cut
+				word = word[cut:]
+				start, width, blank = off, 0, true
+				continue
+			}
+			if !blank {
+				flush()
+				continue
+			}
+			if start < off {
+				// only indentation so far; the word cannot fit after it
+				start, width = off, 0
+				continue
+			}
+			pieces := breakWord(word, opts.wrap, opts.longWords)
+			for _, piece := range pieces[:len(pieces)-1] {
+				spans = append(spans, span{start: off, end: off + len(piece)})
+				off += len(piece)
+			}
+			start = off
+			break
+		}
+		width += stringWidth(line[off:end])
+		off = end
+		if word != "" {
+			blank = false
+		}
+		if seg.Mandatory {
+			flush()
+		}
+	}
+	if start < len(line) {
+		flush()
+	}
+	return spans
+}
+
+// hyphenCut picks the last of the hyphenation points of a word that leaves
+// at most avail columns between from, where the rest of the word starts, and
+// the point. It returns the length of the piece to cut off, or 0 if no
+// point fits.
+func hyphenCut(word string, points []int, from, avail int) int {
+	cut := 0
+	for _, p := range points {
+		if p <= from {
+			continue
+		}
+		if stringWidth(word[from:p]) > avail {
+			break
+		}
+		cut = p - from
+	}
+	return cut
+}
+
+// hyphenPenalty is added to the badness of a line ending in a hyphen, so
+// that wrapOptimal only hyphenates when it saves a fair amount of space.
+const hyphenPenalty = 10
+
+// box is an unbreakable piece of a line for wrapOptimal: a segment, a
+// syllable of a hyphenated word, or a piece of an over-long word that
+// breakWord split.
+type box struct {
+	start, end int  // byte range in the line, including trailing spaces
+	textEnd    int  // end of the text without the trailing spaces
+	width      int  // columns from start to end
+	textWidth  int  // columns from start to textEnd
+	mandatory  bool // the line must break after the box
+	hyphen     bool // a break after the box needs a hyphen
+}
+
+// wrapOptimal wraps a line like wrapLine, but instead of filling each line
+// in turn it picks the breaks that minimise the total badness of the
+// paragraph. The badness of a line is the square of its unused columns.
+// Unlike TeX, which gives the last line of a paragraph away for free, the
+// last line counts as well, so a lone short word at the end is avoided. The
+// breaks are found by dynamic programming over the break opportunities;
+// since a line holds at most wrap columns, the cost is linear in the length
+// of the line for a fixed wrap width.
+func wrapOptimal(line string, opts balloonOptions) []span {
+	var boxes []box
+	off := 0
+	for _, seg := range lineSegments(line, opts.lineBreak) {
+		end := off + len(seg.Text)
+		word := strings.TrimRight(seg.Text, " ")
+		var cuts []int
+		if opts.hyphenator != nil {
+			cuts = opts.hyphenator.Points(word)
+		}
+		// one box per syllable, and the last one takes the trailing spaces
+		prev := 0
+		for k := 0; k <= len(cuts); k++ {
+			cut := len(word)
+			if k < len(cuts) {
+				cut = cuts[k]
+			}
+			pieces := breakWord(word[prev:cut], opts.wrap, opts.longWords)
+			for _, piece := range pieces[:len(pieces)-1] {
+				w := stringWidth(piece)
+				boxes = append(boxes, box{start: off, end: off + len(piece), textEnd: off + len(piece), width: w, textWidth: w, mandatory: true})
+				off += len(piece)
+			}
+			last := pieces[len(pieces)-1]
+			if k < len(cuts) {
+				w := stringWidth(last)
+				boxes = append(boxes, box{start: off, end: off + len(last), textEnd: off + len(last), width: w, textWidth: w, hyphen: true})
+				off += len(last)
+				prev = cut
+				continue
+			}
+			textEnd := off + len(last)
+			boxes = append(boxes, box{
+				start:     off,
+				end:       end,
+				textEnd:   textEnd,
+				width:     stringWidth(line[off:end]),
+				textWidth: stringWidth(line[off:textEnd]),
+				mandatory: seg.Mandatory,
+			})
+		}
+		off = end
+	}
+	// as in wrapLine, drop indentation that the first word does not fit after
+	if len(boxes) > 1 && boxes[0].textWidth == 0 && boxes[0].width+boxes[1].textWidth > opts.wrap {
+		boxes = boxes[1:]
+	}
+
+	var spans []span
+	for len(boxes) > 0 {
+		n := 1
+		for n < len(boxes) && !boxes[n-1].mandatory {
+			n++
+		}
+		spans = append(spans, fitBoxes(boxes[:n], opts.wrap)...)
+		boxes = boxes[n:]
+	}
+	return spans
+}
+
+// fitBoxes breaks a run of boxes without mandatory breaks into the lines
+// with the least total badness.
+func fitBoxes(boxes []box, wrap int) []span {
+	n := len(boxes)
+	cost := make([]int, n+1) // cost[j] is the least badness of boxes[:j]
+	from := make([]int, n+1) // from[j] is where the last line of boxes[:j] starts
+	for j := 1; j <= n; j++ {
+		cost[j] = math.MaxInt
+	}
+	for i := 0; i < n; i++ {
+		width := 0
+		for j := i + 1; j <= n; j++ {
+			w, penalty := width+boxes[j-1].textWidth, 0
+			if boxes[j-1].hyphen {
+				w, penalty = w+1, hyphenPenalty
+			}
+			if w > wrap && j > i+1 {
+				break
+			}
+			// a box wider than wrap has a line of its own at no cost
+			slack := max(wrap-w, 0)
+			if c := cost[i] + slack*slack + penalty; c < cost[j] {
+				cost[j], from[j] = c, i
+			}
+			width += boxes[j-1].width
+		}
+	}
+
+	spans := make([]span, 0, n)
+	for j := n; j > 0; j = from[j] {
+		spans = append(spans, span{start: boxes[from[j]].start, end: boxes[j-1].textEnd, hyphen: boxes[j-1].hyphen})
+	}
+	slices.Reverse(spans)
+	return spans
+}
+
+// justify widens the gaps between words in line, after any indentation,
+// until the line is width columns wide. The extra spaces are spread evenly,
+// with the leftmost gaps getting one more when they do not divide equally.
+func justify(line string, width int) string {
+	var gaps []int // byte offsets of the runs of spaces between words
+	text := false
+	for i := 0; i < len(line); {
+		switch line[i] {
+		case esc:
+			i += ansi.EscapeLen(line[i:])
+			continue
+		case ' ':
+			if text && line[i-1] != ' ' {
+				gaps = append(gaps, i)
+			}
+		default:
+			text = true
+		}
+		i++
+	}
+	extra := width - stringWidth(line)
+	if len(gaps) == 0 || extra <= 0 {
+		return line
+	}
+
+	var b strings.Builder
+	prev := 0
+	for k, gap := range gaps {
+		n := extra / len(gaps)
+		if k < extra%len(gaps) {
+			n++
+		}
+		b.WriteString(line[prev:gap])
+		b.WriteString(strings.Repeat(" ", n))
+		prev = gap
+	}
+	b.WriteString(line[prev:])
+	return b.String()
+}
+
+// lineSegments splits a line into the segments that wrapLine may put on
+// separate lines. Each segment keeps the spaces that follow it.
+func lineSegments(line string, breaking LineBreaking) []unitext.LineSegment {
+	if breaking == LineBreakUnicode {
+		return unitext.LineSegments(line)
+	}
+
+	var segments []unitext.LineSegment
+	for _, tok := range splitSpaces(line) {
+		if n := len(segments); n > 0 && strings.TrimSpace(tok) == "" && strings.TrimSpace(segments[n-1].Text) != "" {
+			segments[n-1].Text += tok
+			continue
+		}
+		segments = append(segments, unitext.LineSegment{Text: tok})
+	}
+	return segments
+}
+
+// breakWord splits a word wider than wrap into pieces according to the
+// policy. Words that fit, and all words under LongWordKeep, are returned
+// whole.
+func breakWord(word string, wrap int, policy LongWordPolicy) []string {
+	if policy == LongWordKeep || stringWidth(word) <= wrap {
+		return []string{word}
+	}
+
+	var pieces []string
+	for stringWidth(word) > wrap {
+		// find the longest prefix that fits, and the last separator in it,
+		// without splitting grapheme clusters
+		cut, sepCut, width := 0, 0, 0
+		for cut < len(word) {
+			cluster, w := unitext.FirstGrapheme(word[cut:])
+			if width+w > wrap && cut > 0 {
+				break
+			}
+			width += w
+			cut += len(cluster)
+			if strings.ContainsAny(cluster, wordSeparators) && cut < len(word) {
+				sepCut = cut
+			}
+		}
+		if policy == LongWordBreakAtSeparators && sepCut > 0 {
+			cut = sepCut
+		}
+		if cut == len(word) {
+			// a single cluster wider than wrap is the last piece
+			break
+		}
+		pieces = append(pieces, word[:cut])
+		word = word[cut:]
+	}
+	return append(pieces, word)
+}
+
+// splitSpaces splits s into alternating runs of spaces and non-spaces.
+func splitSpaces(s string) []string {
+	var toks []string
+	start := 0
+	for i := 1; i <= len(s); i++ {
+		if i == len(s) || (s[i] == ' ') != (s[start] == ' ') {
+			toks = append(toks, s[start:i])
+			start = i
+		}
+	}
+	return toks
+}
+
+// expandTabs replaces tabs with spaces up to the next tab stop.
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for len(s) > 0 {
+		if s[0] == '\t' {
+			n := tabWidth - col%tabWidth
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			s = s[1:]
+			continue
+		}
+		if s[0] == esc {
+			n := ansi.EscapeLen(s)
+			b.WriteString(s[:n])
+			s = s[n:]
+			continue
+		}
+		cluster, w := unitext.FirstGrapheme(s)
+		b.WriteString(cluster)
+		col += w
+		s = s[len(cluster):]
+	}
+	return b.String()
 }
@@ -23,7 +23,11 @@
 package cowsay
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/xogas/cowsay-go/internal/hyphen"
 )
 
 func TestBuildBalloon(t *testing.T) {
@@ -31,6 +35,14 @@ func TestBuildBalloon(t *testing.T) {
 		name    string
 		msg     string
 		wrap    int
+		think   bool
+		style   string
+		mode    WrapMode
+		align   Alignment
+		fit     LineFitting
+		breakAt LineBreaking
+		long    LongWordPolicy
+		hyphen  string
 		wantMsg string
 	}{
 		{
@@ -43,7 +55,285 @@ func TestBuildBalloon(t *testing.T) {
 			name:    "long message",
 			msg:     "aa bb cc dd ee",
 			wrap:    4,
-			wantMsg: " ____\n/ aa \\\n| bb  |\n| cc  |\n| dd  |\n\\ ee /\n ----",
+			wantMsg: " ____\n/ aa \\\n| bb |\n| cc |\n| dd |\n\\ ee /\n ----",
+		},
+		{
+			name:    "short thought",
+			msg:     "hmm",
+			wrap:    40,
+			think:   true,
+			wantMsg: " _____\n( hmm )\n -----",
+		},
+		{
+			name:    "long thought",
+			msg:     "aa bb cc",
+			wrap:    4,
+			think:   true,
+			wantMsg: " ____\n( aa )\n( bb )\n( cc )\n ----",
+		},
+		{
+			name:    "line breaks and blank lines are kept",
+			msg:     "first\n\nsecond line",
+			wrap:    40,
+			wantMsg: " _____________\n/ first       \\\n|             |\n\\ second line /\n -------------",
+		},
+		{
+			name:    "each line is wrapped on its own",
+			msg:     "aa bb cc\ndd",
+			wrap:    5,
+			wantMsg: " _______\n/ aa bb \\\n| cc    |\n\\ dd    /\n -------",
+		},
+		{
+			name:    "spacing within a line is kept",
+			msg:     "a    b\n\tc",
+			wrap:    40,
+			wantMsg: " ___________\n/ a    b    \\\n\\         c /\n -----------",
+		},
+		{
+			name:    "indentation is kept when wrapping",
+			msg:     "  aa bb cc",
+			wrap:    6,
+			wantMsg: " _______\n/   aa  \\\n\\ bb cc /\n -------",
+		},
+		{
+			name:    "long words are kept by default",
+			msg:     "see abcdefgh",
+			wrap:    5,
+			wantMsg: " __________\n/ see      \\\n\\ abcdefgh /\n ----------",
+		},
+		{
+			name:    "long words broken at the column",
+			msg:     "see abcdefgh x",
+			wrap:    5,
+			long:    LongWordBreak,
+			wantMsg: " _______\n/ see   \\\n| abcde |\n\\ fgh x /\n -------",
+		},
+		{
+			name:    "long words broken at separators",
+			msg:     "a.io/b/cdefgh",
+			wrap:    7,
+			long:    LongWordBreakAtSeparators,
+			wantMsg: " _________\n/ a.io/b/ \\\n\\ cdefgh  /\n ---------",
+		},
+		{
+			name:    "long words broken at separators falls back to the column",
+			msg:     "abcdefghij",
+			wrap:    4,
+			long:    LongWordBreakAtSeparators,
+			wantMsg: " ______\n/ abcd \\\n| efgh |\n\\ ij   /\n ------",
+		},
+		{
+			name:    "long full-width words broken at the column",
+			msg:     "你好世界",
+			wrap:    5,
+			long:    LongWordBreak,
+			wantMsg: " ______\n/ 你好 \\\n\\ 世界 /\n ------",
+		},
+		{
+			name:    "full-width characters wider than the wrap width",
+			msg:     "你好世",
+			wrap:    1,
+			long:    LongWordBreak,
+			wantMsg: " ____\n/ 你 \\\n| 好 |\n\\ 世 /\n ----",
+		},
+		{
+			name:    "optimal fitting of characters wider than the wrap width",
+			msg:     "你好世",
+			wrap:    1,
+			fit:     FitOptimal,
+			long:    LongWordBreak,
+			wantMsg: " ____\n/ 你 \\\n| 好 |\n\\ 世 /\n ----",
+		},
+		{
+			name:    "reflowed long words broken at the column",
+			msg:     "ab\ncdefgh",
+			wrap:    4,
+			mode:    WrapReflow,
+			long:    LongWordBreak,
+			wantMsg: " ______\n/ ab   \\\n| cdef |\n\\ gh   /\n ------",
+		},
+		{
+			name:    "reflow joins lines",
+			msg:     "aa\nbb\n\ncc   dd",
+			wrap:    40,
+			mode:    WrapReflow,
+			wantMsg: " _____________\n< aa bb cc dd >\n -------------",
+		},
+		{
+			name:    "chinese wraps between ideographs",
+			msg:     "我们今天去公园玩",
+			wrap:    6,
+			wantMsg: " ________\n/ 我们今 \\\n| 天去公 |\n\\ 园玩   /\n --------",
+		},
+		{
+			name:    "closing punctuation stays on the line",
+			msg:     "你好世界。",
+			wrap:    8,
+			wantMsg: " ________\n/ 你好世 \\\n\\ 界。   /\n --------",
+		},
+		{
+			name:    "small kana and brackets do not start a line",
+			msg:     "「ちょっと」待って",
+			wrap:    8,
+			wantMsg: " __________\n/ 「ちょっ \\\n| と」待っ |\n\\ て       /\n ----------",
+		},
+		{
+			name:    "hyphens are break opportunities",
+			msg:     "well-known fact",
+			wrap:    8,
+			wantMsg: " _______\n/ well- \\\n| known |\n\\ fact  /\n -------",
+		},
+		{
+			name:    "breaking at spaces only",
+			msg:     "well-known 你好世界",
+			wrap:    8,
+			breakAt: LineBreakSpaces,
+			wantMsg: " ____________\n/ well-known \\\n\\ 你好世界   /\n ------------",
+		},
+		{
+			name:    "escape sequences take no space",
+			msg:     "\x1b[31mred\x1b[0m text",
+			wrap:    40,
+			wantMsg: " __________\n< \x1b[31mred\x1b[0m text >\n ----------",
+		},
+		{
+			name:    "colors are carried across wrapped lines",
+			msg:     "\x1b[31maa bb\x1b[0m",
+			wrap:    2,
+			wantMsg: " ____\n/ \x1b[31maa\x1b[0m \\\n\\ \x1b[31mbb\x1b[0m /\n ----",
+		},
+		{
+			name:    "colors are reset before the padding",
+			msg:     "\x1b[1mbold\nx",
+			wrap:    40,
+			wantMsg: " ______\n/ \x1b[1mbold\x1b[0m \\\n\\ \x1b[1mx\x1b[0m    /\n ------",
+		},
+		{
+			name:    "hyperlinks take no space",
+			msg:     "\x1b]8;;https://go.dev\x1b\\go\x1b]8;;\x1b\\",
+			wrap:    40,
+			wantMsg: " ____\n< \x1b]8;;https://go.dev\x1b\\go\x1b]8;;\x1b\\ >\n ----",
+		},
+		{
+			name:    "rounded style",
+			msg:     "aa bb cc",
+			wrap:    2,
+			style:   "rounded",
+			wantMsg: "╭────╮\n│ aa │\n│ bb │\n│ cc │\n╰────╯",
+		},
+		{
+			name:    "heavy style single line",
+			msg:     "hi",
+			wrap:    40,
+			style:   "heavy",
+			wantMsg: "┏━━━━┓\n┃ hi ┃\n┗━━━━┛",
+		},
+		{
+			name:    "double style thought",
+			msg:     "hmm",
+			wrap:    40,
+			think:   true,
+			style:   "double",
+			wantMsg: "╔═════╗\n║ hmm ║\n╚═════╝",
+		},
+		{
+			name:    "centered",
+			msg:     "a bb ccc dd e",
+			wrap:    6,
+			align:   AlignCenter,
+			wantMsg: " ________\n/  a bb  \\\n| ccc dd |\n\\   e    /\n --------",
+		},
+		{
+			name:    "right aligned",
+			msg:     "a bb ccc dd e",
+			wrap:    6,
+			align:   AlignRight,
+			wantMsg: " ________\n/   a bb \\\n| ccc dd |\n\\      e /\n --------",
+		},
+		{
+			name:    "justified with a ragged last line",
+			msg:     "a bb ccc dd e",
+			wrap:    6,
+			align:   AlignJustify,
+			wantMsg: " ________\n/ a   bb \\\n| ccc dd |\n\\ e      /\n --------",
+		},
+		{
+			name:    "justified spaces spread evenly",
+			msg:     "a b c dddddddd\nend",
+			wrap:    8,
+			align:   AlignJustify,
+			wantMsg: " __________\n/ a   b  c \\\n| dddddddd |\n\\ end      /\n ----------",
+		},
+		{
+			name:    "justified keeps indentation and colors",
+			msg:     "  \x1b[1ma b\x1b[0m c\nxxxxxxx",
+			wrap:    6,
+			align:   AlignJustify,
+			wantMsg: " _________\n/   \x1b[1ma   b\x1b[0m \\\n| c       |\n\\ xxxxxxx /\n ---------",
+		},
+		{
+			name:    "greedy fitting",
+			msg:     "aaa bb cc ddddd",
+			wrap:    6,
+			wantMsg: " ________\n/ aaa bb \\\n| cc     |\n\\ ddddd  /\n --------",
+		},
+		{
+			name:    "optimal fitting",
+			msg:     "aaa bb cc ddddd",
+			wrap:    6,
+			fit:     FitOptimal,
+			wantMsg: " _______\n/ aaa   \\\n| bb cc |\n\\ ddddd /\n -------",
+		},
+		{
+			name:    "optimal fitting avoids a lone last word",
+			msg:     "aa bb cc dd ee ff gg hh ii",
+			wrap:    12,
+			fit:     FitOptimal,
+			wantMsg: " __________\n/ aa bb cc \\\n| dd ee ff |\n\\ gg hh ii /\n ----------",
+		},
+		{
+			name:    "optimal fitting keeps indentation and mandatory breaks",
+			msg:     "  aa bb cc\u2028dd ee",
+			wrap:    6,
+			fit:     FitOptimal,
+			wantMsg: " ________\n/   aa   \\\n| bb cc\u2028 |\n\\ dd ee  /\n --------",
+		},
+		{
+			name:    "optimal fitting breaks long words",
+			msg:     "ab cdefghij k",
+			wrap:    4,
+			fit:     FitOptimal,
+			long:    LongWordBreak,
+			wantMsg: " ______\n/ ab   \\\n| cdef |\n| ghij |\n\\ k    /\n ------",
+		},
+		{
+			name:    "hyphenation fills the line",
+			msg:     "the presentation",
+			wrap:    10,
+			hyphen:  "en-us",
+			wantMsg: " ___________\n/ the pre-  \\\n\\ sentation /\n -----------",
+		},
+		{
+			name:    "hyphenation splits a long word several times",
+			msg:     "Silbentrennung",
+			wrap:    5,
+			hyphen:  "de",
+			wantMsg: " _______\n/ Sil-  \\\n| ben-  |\n| tren- |\n\\ nung  /\n -------",
+		},
+		{
+			name:    "hyphenation with optimal fitting",
+			msg:     "an extraordinaire idée",
+			wrap:    9,
+			fit:     FitOptimal,
+			hyphen:  "fr",
+			wantMsg: " ___________\n/ an extra- \\\n| ordinaire |\n\\ idée      /\n -----------",
+		},
+		{
+			name:    "no wrap sizes to the longest line",
+			msg:     "Filesystem  Size  Used\n/dev/sda1    50G   20G",
+			wrap:    10,
+			mode:    WrapNone,
+			wantMsg: " ________________________\n/ Filesystem  Size  Used \\\n\\ /dev/sda1    50G   20G /\n ------------------------",
 		},
 		{
 			name:    "full-width unicode handled correctly",
@@ -51,14 +341,57 @@ func TestBuildBalloon(t *testing.T) {
 			wrap:    40,
 			wantMsg: " ______\n< 你好 >\n ------",
 		},
+		{
+			name:    "accented latin and box drawing are one column",
+			msg:     "café ─ Привет",
+			wrap:    40,
+			wantMsg: " _______________\n< café ─ Привет >\n ---------------",
+		},
+		{
+			name:    "emoji sequences are two columns",
+			msg:     "🇯🇵 👨\u200d👩\u200d👧",
+			wrap:    40,
+			wantMsg: " _______\n< 🇯🇵 👨\u200d👩\u200d👧 >\n -------",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := buildBalloon(tc.msg, tc.wrap)
+			opts := balloonOptions{wrap: tc.wrap, think: tc.think, mode: tc.mode, align: tc.align, fitting: tc.fit, lineBreak: tc.breakAt, longWords: tc.long}
+			if tc.hyphen != "" {
+				h, err := hyphen.Load(tc.hyphen)
+				if err != nil {
+					t.Fatal(err)
+				}
+				opts.hyphenator = h
+			}
+			if tc.style != "" {
+				style, err := ParseBalloonStyle(tc.style)
+				if err != nil {
+					t.Fatal(err)
+				}
+				opts.style = style
+			}
+			got := buildBalloon(tc.msg, opts)
 			if tc.wantMsg != string(got) {
 				t.Fatalf("expected message balloon, got: %q", string(got))
 			}
 		})
 	}
 }
+
+func BenchmarkBuildBalloon(b *testing.B) {
+	const sentence = "The quick brown fox jumps over the lazy dog, and 敏捷的棕色狐狸跳过了懒狗。 "
+	for _, size := range []int{10 << 10, 100 << 10} {
+		msg := strings.Repeat(sentence, size/len(sentence)+1)[:size]
+		for _, fit := range []LineFitting{FitGreedy, FitOptimal} {
+			b.Run(fmt.Sprintf("%s/%dKB", fit, size>>10), func(b *testing.B) {
+				opts := balloonOptions{wrap: 40, mode: WrapReflow, fitting: fit}
+				b.SetBytes(int64(len(msg)))
+				for b.Loop() {
+					buildBalloon(msg, opts)
+				}
+			})
+		}
+	}
+}
@@ -23,8 +23,6 @@
 package cowsay
 
 import (
-	"bytes"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -32,6 +30,7 @@ import (
 	"strings"
 
 	"github.com/xogas/cowsay-go/assets"
+	"github.com/xogas/cowsay-go/internal/hyphen"
 )
 
 // LocationType represents the location of a cow asset.
@@ -44,12 +43,29 @@ const (
 	InDirectory
 )
 
+// Default faces used by NewCow.
+const (
+	DefaultEyes   = "oo"
+	DefaultTongue = "  "
+)
+
 // Cow represents a talking cow.
 type Cow struct {
-	Name     string
-	BasePath string
-	Location LocationType
-	Wrap     int
+	Name      string
+	BasePath  string
+	Location  LocationType
+	Wrap      int
+	WrapMode  WrapMode
+	Align     Alignment      // where the text sits in each line of the balloon
+	Fitting   LineFitting    // how the breaks of a wrapped paragraph are chosen
+	LineBreak LineBreaking   // where lines may be broken when wrapping
+	LongWords LongWordPolicy // how to wrap words wider than Wrap
+	Hyphenate string         // hyphenation language, such as "en-us"; empty for none
+	Think     bool           // draw a thought balloon, as cowthink does
+	Sanitize  SanitizePolicy // escapes in the message; NewCow strips them
+	Style     BalloonStyle   // balloon glyphs; the zero value is the ascii style
+	Eyes      string         // substituted for $eyes in the cow file
+	Tongue    string         // substituted for $tongue in the cow file
 }
 
 // NewCow creates a new Cow instance.
@@ -65,9 +81,17 @@ func NewCow(name, basePath string, location LocationType) *Cow {
 		BasePath: basePath,
 		Location: location,
 		Wrap:     40,
+		Sanitize: SanitizeStrip,
+		Eyes:     DefaultEyes,
+		Tongue:   DefaultTongue,
 	}
 }
 
+// HyphenationLanguages returns the languages Hyphenate accepts.
+func HyphenationLanguages() []string {
+	return hyphen.Languages()
+}
+
 // AvailableCows lists all available cow names from the specified location.
 func AvailableCows(basePath string, location LocationType) ([]string, error) {
 	var names []string
@@ -94,14 +118,74 @@ func AvailableCows(basePath string, location LocationType) ([]string, error) {
 
 // Render builds the speech balloon and append the cow art.
 func (c *Cow) Render(msg string) ([]byte, error) {
+	grid, err := c.RenderGrid(msg)
+	if err != nil {
+		return nil, err
+	}
+	return grid.Bytes(), nil
+}
+
+// RenderGrid renders the cow like Render, but as a grid of cells that
+// tells the balloon, the message and the parts of the cow apart.
+func (c *Cow) RenderGrid(msg string) (Grid, error) {
+	msg = Sanitize(msg, c.Sanitize)
 	if strings.TrimSpace(msg) == "" {
 		msg = "Hello, World!"
 	}
 
+	vars, err := c.templateVars()
+	if err != nil {
+		return nil, err
+	}
+	var hyphenator *hyphen.Hyphenator
+	if c.Hyphenate != "" {
+		hyphenator, err = hyphen.Load(c.Hyphenate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// balloon
-	balloon := buildBalloon(msg, c.Wrap)
+	balloon := balloonGrid(msg, balloonOptions{
+		wrap:       c.Wrap,
+		think:      c.Think,
+		style:      c.balloonStyle(),
+		mode:       c.WrapMode,
+		align:      c.Align,
+		fitting:    c.Fitting,
+		lineBreak:  c.LineBreak,
+		longWords:  c.LongWords,
+		hyphenator: hyphenator,
+	})
+
+	art, err := c.art(vars)
+	if err != nil {
+		return nil, err
+	}
+	return append(balloon, art...), nil
+}
+
+// minAutoWrap is the narrowest wrap width FitWidth picks.
+const minAutoWrap = 20
 
-	// load cow data
+// FitWidth sets Wrap for a terminal that is columns wide, leaving room for
+// the cow: the width of the rendered cow art is subtracted from columns, but
+// at least minAutoWrap columns are kept for the text.
+func (c *Cow) FitWidth(columns int) error {
+	vars, err := c.templateVars()
+	if err != nil {
+		return err
+	}
+	art, err := c.art(vars)
+	if err != nil {
+		return err
+	}
+	c.Wrap = max(columns-art.Width(), minAutoWrap)
+	return nil
+}
+
+// art loads the cow file and returns its art with vars substituted.
+func (c *Cow) art(vars map[string]string) (Grid, error) {
 	var data []byte
 	var err error
 	if c.Location == InBinary {
@@ -124,30 +208,47 @@ func (c *Cow) Render(msg string) ([]byte, error) {
 		}
 	}
 
-	// extract art between "<<EOC" and "EOC" (fallback to whole file)
-	startDelimiter := []byte("<<EOC;")
-	endDelimiter := []byte("EOC")
-	art := data
-	if pos := bytes.Index(data, startDelimiter); pos >= 0 {
-		start := pos + len(startDelimiter)
-		if start < len(data) && data[start] == '\n' {
-			start++
-		}
-		if endRelPos := bytes.Index(data[start:], endDelimiter); endRelPos >= 0 {
-			art = data[start : start+endRelPos]
-		} else {
-			art = data[start:]
-		}
+	art, spans, err := parseCow(data, vars)
+	if err != nil {
+		return nil, fmt.Errorf("cow %q: %w", c.Name, err)
+	}
+	return artGrid(art, spans), nil
+}
+
+// balloonStyle returns the style of the balloon, defaulting to ascii.
+func (c *Cow) balloonStyle() BalloonStyle {
+	if c.Style == (BalloonStyle{}) {
+		return balloonStyles[0]
+	}
+	return c.Style
+}
+
+// templateVars returns the variables available to the cow file heredoc.
+// The tongue is padded to two columns so that the art stays aligned.
+func (c *Cow) templateVars() (map[string]string, error) {
+	eyes := c.Eyes
+	if eyes == "" {
+		eyes = DefaultEyes
+	}
+	if err := ValidateEyes(eyes); err != nil {
+		return nil, err
 	}
-	art = bytes.TrimRight(art, "\n")
-	if len(art) == 0 {
-		return []byte{}, errors.New("invalid cow file: no art found")
+	if err := ValidateTongue(c.Tongue); err != nil {
+		return nil, err
 	}
+	tongue := c.Tongue + strings.Repeat(" ", 2-stringWidth(c.Tongue))
 
-	var out bytes.Buffer
-	out.Write(balloon)
-	out.WriteByte('\n')
-	out.Write(art)
-	out.WriteByte('\n')
-	return out.Bytes(), nil
+	style := c.balloonStyle()
+	if err := style.Validate(); err != nil {
+		return nil, fmt.Errorf("balloon style %q: %w", style.Name, err)
+	}
+	thoughts := style.Tail
+	if c.Think {
+		thoughts = style.ThoughtTail
+	}
+	return map[string]string{
+		"eyes":     eyes,
+		"tongue":   tongue,
+		"thoughts": thoughts,
+	}, nil
 }
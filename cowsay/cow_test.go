@@ -23,7 +23,9 @@
 package cowsay_test
 
 import (
+	"flag"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"testing"
@@ -32,6 +34,8 @@ import (
 	"github.com/xogas/cowsay-go/cowsay"
 )
 
+var update = flag.Bool("update", false, "update golden files")
+
 func TestCow(t *testing.T) {
 	wantMsg, err := os.ReadFile("./testdata/default.cow")
 	if err != nil {
@@ -128,3 +132,75 @@ func TestAvailableCows(t *testing.T) {
 	}
 
 }
+
+func TestBundledCowsGolden(t *testing.T) {
+	for _, name := range assets.CowInBinary() {
+		t.Run(name, func(t *testing.T) {
+			got, err := cowsay.NewCow(name, "", cowsay.InBinary).Render("Hello!")
+			if err != nil {
+				t.Fatalf("Render() unexpected error: %v", err)
+			}
+
+			golden := filepath.Join("testdata", "golden", name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if string(want) != string(got) {
+				t.Fatalf("Render() mismatch for %s\ngot:\n%s\nwant:\n%s", name, got, want)
+			}
+		})
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		cow      *cowsay.Cow
+		columns  int
+		wantWrap int
+		hasErr   bool
+	}{
+		{
+			name:     "cow width is subtracted",
+			cow:      cowsay.NewCow("default", "", cowsay.InBinary),
+			columns:  100,
+			wantWrap: 72,
+		},
+		{
+			name:     "narrow terminal keeps a minimum",
+			cow:      cowsay.NewCow("default", "", cowsay.InBinary),
+			columns:  30,
+			wantWrap: 20,
+		},
+		{
+			name:    "missing cow",
+			cow:     cowsay.NewCow("nosuchcow", "", cowsay.InBinary),
+			columns: 100,
+			hasErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cow.FitWidth(tc.columns)
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("FitWidth(%d) expected error but got none", tc.columns)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FitWidth(%d) unexpected error: %v", tc.columns, err)
+			}
+			if tc.cow.Wrap != tc.wantWrap {
+				t.Errorf("FitWidth(%d) set Wrap = %d, want %d", tc.columns, tc.cow.Wrap, tc.wantWrap)
+			}
+		})
+	}
+}
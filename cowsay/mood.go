@@ -0,0 +1,115 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"fmt"
+)
+
+// Mood is a preset face for the cow, as selected by the classic cowsay
+// -b, -d, -g, -p, -s, -t, -w and -y options.
+type Mood int
+
+const (
+	// MoodNormal is the default face.
+	MoodNormal Mood = iota
+	// MoodBorg gives the cow "==" eyes.
+	MoodBorg
+	// MoodDead gives the cow "xx" eyes and a tongue.
+	MoodDead
+	// MoodGreedy gives the cow "$$" eyes.
+	MoodGreedy
+	// MoodParanoid gives the cow "@@" eyes.
+	MoodParanoid
+	// MoodStoned gives the cow "**" eyes and a tongue.
+	MoodStoned
+	// MoodTired gives the cow "--" eyes.
+	MoodTired
+	// MoodWired gives the cow "OO" eyes.
+	MoodWired
+	// MoodYouthful gives the cow ".." eyes.
+	MoodYouthful
+)
+
+var moods = []struct {
+	name   string
+	eyes   string
+	tongue string
+}{
+	MoodNormal:   {"normal", DefaultEyes, DefaultTongue},
+	MoodBorg:     {"borg", "==", DefaultTongue},
+	MoodDead:     {"dead", "xx", "U "},
+	MoodGreedy:   {"greedy", "$$", DefaultTongue},
+	MoodParanoid: {"paranoid", "@@", DefaultTongue},
+	MoodStoned:   {"stoned", "**", "U "},
+	MoodTired:    {"tired", "--", DefaultTongue},
+	MoodWired:    {"wired", "OO", DefaultTongue},
+	MoodYouthful: {"youthful", "..", DefaultTongue},
+}
+
+// String returns the name of the mood.
+func (m Mood) String() string {
+	if m < 0 || int(m) >= len(moods) {
+		return fmt.Sprintf("Mood(%d)", int(m))
+	}
+	return moods[m].name
+}
+
+// Face returns the eyes and tongue of the mood.
+func (m Mood) Face() (eyes, tongue string) {
+	if m < 0 || int(m) >= len(moods) {
+		return DefaultEyes, DefaultTongue
+	}
+	return moods[m].eyes, moods[m].tongue
+}
+
+// ParseMood returns the mood with the given name.
+func ParseMood(name string) (Mood, error) {
+	for i, m := range moods {
+		if m.name == name {
+			return Mood(i), nil
+		}
+	}
+	return MoodNormal, fmt.Errorf("unknown mood %q", name)
+}
+
+// SetMood sets the eyes and tongue of the cow to those of the mood.
+func (c *Cow) SetMood(m Mood) {
+	c.Eyes, c.Tongue = m.Face()
+}
+
+// ValidateEyes checks that eyes are exactly two display columns wide.
+func ValidateEyes(eyes string) error {
+	if w := stringWidth(eyes); w != 2 {
+		return fmt.Errorf("eyes %q must be exactly 2 columns wide, got %d", eyes, w)
+	}
+	return nil
+}
+
+// ValidateTongue checks that a tongue is at most two display columns wide.
+func ValidateTongue(tongue string) error {
+	if w := stringWidth(tongue); w > 2 {
+		return fmt.Errorf("tongue %q must be at most 2 columns wide, got %d", tongue, w)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/cowsay"
+)
+
+func TestParseMood(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantMood   cowsay.Mood
+		wantEyes   string
+		wantTongue string
+		hasErr     bool
+	}{
+		{name: "normal", wantMood: cowsay.MoodNormal, wantEyes: "oo", wantTongue: "  "},
+		{name: "borg", wantMood: cowsay.MoodBorg, wantEyes: "==", wantTongue: "  "},
+		{name: "dead", wantMood: cowsay.MoodDead, wantEyes: "xx", wantTongue: "U "},
+		{name: "greedy", wantMood: cowsay.MoodGreedy, wantEyes: "$$", wantTongue: "  "},
+		{name: "paranoid", wantMood: cowsay.MoodParanoid, wantEyes: "@@", wantTongue: "  "},
+		{name: "stoned", wantMood: cowsay.MoodStoned, wantEyes: "**", wantTongue: "U "},
+		{name: "tired", wantMood: cowsay.MoodTired, wantEyes: "--", wantTongue: "  "},
+		{name: "wired", wantMood: cowsay.MoodWired, wantEyes: "OO", wantTongue: "  "},
+		{name: "youthful", wantMood: cowsay.MoodYouthful, wantEyes: "..", wantTongue: "  "},
+		{name: "grumpy", hasErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mood, err := cowsay.ParseMood(tc.name)
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("ParseMood(%q) expected error but got none", tc.name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMood(%q) unexpected error: %v", tc.name, err)
+			}
+			if mood != tc.wantMood || mood.String() != tc.name {
+				t.Fatalf("ParseMood(%q) = %v, want %v", tc.name, mood, tc.wantMood)
+			}
+			eyes, tongue := mood.Face()
+			if eyes != tc.wantEyes || tongue != tc.wantTongue {
+				t.Fatalf("%v.Face() = %q, %q, want %q, %q", mood, eyes, tongue, tc.wantEyes, tc.wantTongue)
+			}
+		})
+	}
+}
+
+func TestFaceValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		eyes   string
+		tongue string
+		hasErr bool
+	}{
+		{name: "defaults", eyes: "oo", tongue: "  "},
+		{name: "empty tongue", eyes: "^^", tongue: ""},
+		{name: "one column tongue", eyes: "^^", tongue: "U"},
+		{name: "full-width eye", eyes: "目", tongue: ""},
+		{name: "one column eyes", eyes: "o", tongue: "", hasErr: true},
+		{name: "three column eyes", eyes: "ooo", tongue: "", hasErr: true},
+		{name: "three column tongue", eyes: "oo", tongue: "UUU", hasErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := cowsay.NewCow("default", "", cowsay.InBinary)
+			c.Eyes = tc.eyes
+			c.Tongue = tc.tongue
+			_, err := c.Render("moo")
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("Render() with eyes %q, tongue %q expected error but got none", tc.eyes, tc.tongue)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() with eyes %q, tongue %q unexpected error: %v", tc.eyes, tc.tongue, err)
+			}
+		})
+	}
+}
+
+func TestSetMood(t *testing.T) {
+	c := cowsay.NewCow("default", "", cowsay.InBinary)
+	c.SetMood(cowsay.MoodDead)
+
+	got, err := c.Render("moo")
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	for _, want := range []string{"(xx)", "U  ||----w |"} {
+		if !strings.Contains(string(got), want) {
+			t.Fatalf("Render() = %q, want it to contain %q", got, want)
+		}
+	}
+}
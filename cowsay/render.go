@@ -0,0 +1,187 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+	"github.com/xogas/cowsay-go/internal/unitext"
+)
+
+// Region is the part of a rendered cow that a cell belongs to.
+type Region int
+
+const (
+	// RegionBorder is the frame of the balloon.
+	RegionBorder Region = iota
+	// RegionText is the message inside the balloon, padding included.
+	RegionText
+	// RegionTail is the trail from the balloon to the cow: $thoughts in
+	// the cow file.
+	RegionTail
+	// RegionBody is the cow art apart from its face.
+	RegionBody
+	// RegionEyes is $eyes in the cow file.
+	RegionEyes
+	// RegionTongue is $tongue in the cow file.
+	RegionTongue
+)
+
+var regionNames = []string{
+	RegionBorder: "border",
+	RegionText:   "text",
+	RegionTail:   "tail",
+	RegionBody:   "body",
+	RegionEyes:   "eyes",
+	RegionTongue: "tongue",
+}
+
+// regionGroups are names for several regions at once.
+var regionGroups = map[string][]Region{
+	"balloon": {RegionBorder, RegionText, RegionTail},
+	"cow":     {RegionBody, RegionEyes, RegionTongue},
+}
+
+// String returns the name of the region.
+func (r Region) String() string {
+	if r < 0 || int(r) >= len(regionNames) {
+		return fmt.Sprintf("Region(%d)", int(r))
+	}
+	return regionNames[r]
+}
+
+// ParseRegions returns the regions with the given name: a single region,
+// "balloon" for the border, text and tail, or "cow" for the body, eyes and
+// tongue.
+func ParseRegions(name string) ([]Region, error) {
+	if group, ok := regionGroups[name]; ok {
+		return group, nil
+	}
+	for i, n := range regionNames {
+		if n == name {
+			return []Region{Region(i)}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown region %q", name)
+}
+
+// Cell is one grapheme cluster of a rendered cow, tagged with its region.
+// Text also holds the escape sequences just before the cluster, which take
+// no room; escape sequences at the end of a line make up a cell of their
+// own.
+type Cell struct {
+	Text   string
+	Region Region
+}
+
+// Width returns the number of columns the cell takes on a terminal.
+func (c Cell) Width() int {
+	return stringWidth(c.Text)
+}
+
+// Grid is a rendered cow, line by line.
+type Grid [][]Cell
+
+// Bytes returns the text of the grid, every line ended by a newline.
+func (g Grid) Bytes() []byte {
+	var buf bytes.Buffer
+	for _, row := range g {
+		for _, c := range row {
+			buf.WriteString(c.Text)
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// Width returns the width in columns of the widest line of the grid.
+func (g Grid) Width() int {
+	width := 0
+	for _, row := range g {
+		w := 0
+		for _, c := range row {
+			w += c.Width()
+		}
+		width = max(width, w)
+	}
+	return width
+}
+
+// appendCells splits s into cells of the region and appends them to row.
+func appendCells(row []Cell, s string, region Region) []Cell {
+	pending := ""
+	for len(s) > 0 {
+		if s[0] == esc {
+			n := ansi.EscapeLen(s)
+			pending += s[:n]
+			s = s[n:]
+			continue
+		}
+		cluster, _ := unitext.FirstGrapheme(s)
+		row = append(row, Cell{Text: pending + cluster, Region: region})
+		pending = ""
+		s = s[len(cluster):]
+	}
+	if pending != "" {
+		row = append(row, Cell{Text: pending, Region: region})
+	}
+	return row
+}
+
+// varRegions are the regions of the values of cow file variables.
+var varRegions = map[string]Region{
+	"eyes":     RegionEyes,
+	"tongue":   RegionTongue,
+	"thoughts": RegionTail,
+}
+
+// artGrid splits cow art into cells. The values of the variables at spans
+// get their own regions and everything else is body.
+func artGrid(art []byte, spans []varSpan) Grid {
+	var grid Grid
+	start := 0
+	for _, line := range strings.Split(string(art), "\n") {
+		var row []Cell
+		pos, end := start, start+len(line)
+		for _, sp := range spans {
+			if sp.end <= pos || sp.start >= end {
+				continue
+			}
+			region, ok := varRegions[sp.name]
+			if !ok {
+				region = RegionBody
+			}
+			from, to := max(sp.start, pos), min(sp.end, end)
+			row = appendCells(row, string(art[pos:from]), RegionBody)
+			row = appendCells(row, string(art[from:to]), region)
+			pos = to
+		}
+		row = appendCells(row, string(art[pos:end]), RegionBody)
+		grid = append(grid, row)
+		start = end + 1
+	}
+	return grid
+}
@@ -0,0 +1,130 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/xogas/cowsay-go/cowsay"
+)
+
+// regionText returns the text of all cells of the grid in the region.
+func regionText(g cowsay.Grid, region cowsay.Region) string {
+	var text string
+	for _, row := range g {
+		for _, c := range row {
+			if c.Region == region {
+				text += c.Text
+			}
+		}
+	}
+	return text
+}
+
+func TestRenderGrid(t *testing.T) {
+	c := cowsay.NewCow("default", "", cowsay.InBinary)
+	c.Eyes = "^^"
+	c.Tongue = "U"
+	c.Sanitize = cowsay.SanitizeSGR
+
+	grid, err := c.RenderGrid("Hi \x1b[1myou\x1b[0m")
+	if err != nil {
+		t.Fatalf("RenderGrid failed: %v", err)
+	}
+
+	want := map[cowsay.Region]string{
+		cowsay.RegionBorder: " ________<> --------",
+		cowsay.RegionText:   " Hi \x1b[1myou\x1b[0m ",
+		cowsay.RegionTail:   `\\`,
+		cowsay.RegionEyes:   "^^",
+		cowsay.RegionTongue: "U ",
+	}
+	for region, text := range want {
+		if got := regionText(grid, region); got != text {
+			t.Errorf("%v = %q, want %q", region, got, text)
+		}
+	}
+	if body := regionText(grid, cowsay.RegionBody); !bytes.Contains([]byte(body), []byte("||----w |")) {
+		t.Errorf("body = %q, want it to contain the legs", body)
+	}
+
+	out, err := c.Render("Hi \x1b[1myou\x1b[0m")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !bytes.Equal(grid.Bytes(), out) {
+		t.Fatalf("RenderGrid().Bytes() = %q, want Render() = %q", grid.Bytes(), out)
+	}
+}
+
+func TestGridCells(t *testing.T) {
+	c := cowsay.NewCow("default", "", cowsay.InBinary)
+	grid, err := c.RenderGrid("é好")
+	if err != nil {
+		t.Fatalf("RenderGrid failed: %v", err)
+	}
+
+	// the text line is "< é好 >"
+	var texts []string
+	for _, cell := range grid[1] {
+		texts = append(texts, cell.Text)
+	}
+	want := []string{"<", " ", "é", "好", " ", ">"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Fatalf("cells = %q, want %q", texts, want)
+	}
+	if w := grid[1][3].Width(); w != 2 {
+		t.Fatalf("Width(%q) = %d, want 2", grid[1][3].Text, w)
+	}
+}
+
+func TestParseRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    []cowsay.Region
+		wantErr bool
+	}{
+		{name: "text", want: []cowsay.Region{cowsay.RegionText}},
+		{name: "tongue", want: []cowsay.Region{cowsay.RegionTongue}},
+		{name: "cow", want: []cowsay.Region{cowsay.RegionBody, cowsay.RegionEyes, cowsay.RegionTongue}},
+		{name: "balloon", want: []cowsay.Region{cowsay.RegionBorder, cowsay.RegionText, cowsay.RegionTail}},
+		{name: "udder", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cowsay.ParseRegions(tc.name)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRegions(%q) = %v, want error", tc.name, got)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ParseRegions(%q) = %v, %v, want %v", tc.name, got, err, tc.want)
+			}
+		})
+	}
+}
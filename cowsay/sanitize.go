@@ -0,0 +1,122 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+)
+
+// SanitizePolicy controls what happens to terminal escape sequences and
+// other control characters in a message. Left in, they could move the
+// cursor, retitle the window, ring the bell or, with OSC 52, write to the
+// clipboard of whoever views the cow.
+type SanitizePolicy int
+
+const (
+	// SanitizeSGR keeps SGR sequences, so colored input stays colored, and
+	// strips all other escape sequences and control characters.
+	SanitizeSGR SanitizePolicy = iota
+	// SanitizeStrip strips all escape sequences and control characters.
+	SanitizeStrip
+	// SanitizeEscape shows control characters in caret notation, ESC as
+	// ^[ and BEL as ^G, so escape sequences appear as plain text.
+	SanitizeEscape
+	// SanitizeOff leaves the message as it is. Use it only for trusted
+	// input.
+	SanitizeOff
+)
+
+var sanitizeNames = []string{
+	SanitizeSGR:    "sgr",
+	SanitizeStrip:  "strip",
+	SanitizeEscape: "escape",
+	SanitizeOff:    "off",
+}
+
+// String returns the name of the policy.
+func (p SanitizePolicy) String() string {
+	if p < 0 || int(p) >= len(sanitizeNames) {
+		return fmt.Sprintf("SanitizePolicy(%d)", int(p))
+	}
+	return sanitizeNames[p]
+}
+
+// ParseSanitizePolicy returns the sanitize policy with the given name.
+func ParseSanitizePolicy(name string) (SanitizePolicy, error) {
+	for i, n := range sanitizeNames {
+		if n == name {
+			return SanitizePolicy(i), nil
+		}
+	}
+	return SanitizeSGR, fmt.Errorf("unknown sanitize policy %q", name)
+}
+
+// Sanitize applies the policy to msg. Line breaks and tabs are always
+// kept, with "\r\n" turned into "\n". Control characters include the C1
+// controls U+0080 to U+009F, as some terminals take U+009B for the start
+// of an escape sequence, and bytes that are not valid UTF-8 become U+FFFD
+// for the same reason.
+func Sanitize(msg string, policy SanitizePolicy) string {
+	if policy == SanitizeOff {
+		return msg
+	}
+
+	msg = strings.ReplaceAll(msg, "\r\n", "\n")
+	var b strings.Builder
+	for len(msg) > 0 {
+		r, size := utf8.DecodeRuneInString(msg)
+		switch {
+		case r == esc && policy != SanitizeEscape:
+			n := ansi.EscapeLen(msg)
+			if policy == SanitizeSGR && ansi.IsSGR(msg[:n]) {
+				b.WriteString(msg[:n])
+			}
+			size = n
+		case r == utf8.RuneError && size == 1:
+			b.WriteRune(utf8.RuneError)
+		case r == '\n' || r == '\t' || !unicode.IsControl(r):
+			b.WriteString(msg[:size])
+		case policy == SanitizeEscape:
+			b.WriteString(caret(r))
+		}
+		msg = msg[size:]
+	}
+	return b.String()
+}
+
+// caret returns the control character r in the caret notation of cat -v:
+// ^[ for ESC, ^? for DEL and M-^[ for U+009B.
+func caret(r rune) string {
+	switch {
+	case r == 0x7f:
+		return "^?"
+	case r >= 0x80:
+		return "M-^" + string(r-0x80+0x40)
+	}
+	return "^" + string(r+0x40)
+}
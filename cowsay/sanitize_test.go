@@ -0,0 +1,94 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/cowsay"
+)
+
+func TestSanitize(t *testing.T) {
+	const msg = "\x1b[31mred\x1b[0m\x1b]52;c;cHduZWQ=\a\x1b[2J\x07bell\r\n\tx\u009b1m\xff"
+	tests := []struct {
+		policy cowsay.SanitizePolicy
+		want   string
+	}{
+		{cowsay.SanitizeSGR, "\x1b[31mred\x1b[0mbell\n\tx1m�"},
+		{cowsay.SanitizeStrip, "redbell\n\tx1m�"},
+		{cowsay.SanitizeEscape, "^[[31mred^[[0m^[]52;c;cHduZWQ=^G^[[2J^Gbell\n\txM-^[1m�"},
+		{cowsay.SanitizeOff, msg},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			if got := cowsay.Sanitize(msg, tc.policy); got != tc.want {
+				t.Fatalf("Sanitize(%q, %v) = %q, want %q", msg, tc.policy, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSanitizePolicy(t *testing.T) {
+	for _, name := range []string{"sgr", "strip", "escape", "off"} {
+		p, err := cowsay.ParseSanitizePolicy(name)
+		if err != nil {
+			t.Fatalf("ParseSanitizePolicy(%q) failed: %v", name, err)
+		}
+		if p.String() != name {
+			t.Fatalf("ParseSanitizePolicy(%q) = %v", name, p)
+		}
+	}
+	if _, err := cowsay.ParseSanitizePolicy("scrub"); err == nil {
+		t.Fatal("ParseSanitizePolicy(\"scrub\") succeeded, want an error")
+	}
+}
+
+func TestRenderSanitizes(t *testing.T) {
+	const msg = "copy \x1b]52;c;cHduZWQ=\a\x1b[1;5;8mthis\x1b[0m"
+	c := cowsay.NewCow("default", "", cowsay.InBinary)
+	out, err := c.Render(msg)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if got := string(out); strings.Contains(got, "\x1b") || !strings.Contains(got, "< copy this >") {
+		t.Fatalf("Render with the default policy = %q, want every escape sequence stripped", got)
+	}
+
+	c.Sanitize = cowsay.SanitizeSGR
+	if out, err = c.Render(msg); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if got := string(out); strings.Contains(got, "\x1b]") || !strings.Contains(got, "< copy \x1b[1;5;8mthis\x1b[0m >") {
+		t.Fatalf("Render with SanitizeSGR kept the OSC sequence or lost the SGR ones: %q", got)
+	}
+
+	c.Sanitize = cowsay.SanitizeOff
+	if out, err = c.Render("\x1b]0;title\a"); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(string(out), "\x1b]0;title\a") {
+		t.Fatalf("Render with SanitizeOff = %q, want the OSC sequence kept", out)
+	}
+}
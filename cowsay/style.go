@@ -0,0 +1,276 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BalloonStyle defines the glyphs a balloon is drawn with.
+type BalloonStyle struct {
+	Name string
+
+	// border lines above and below the text
+	TopLeft, Top, TopRight          string
+	BottomLeft, Bottom, BottomRight string
+
+	// edges of the text lines of a speech balloon with several lines
+	FirstLeft, FirstRight string
+	Left, Right           string
+	LastLeft, LastRight   string
+	// edges of a speech balloon with a single line
+	SingleLeft, SingleRight string
+	// edges of every text line of a thought balloon
+	ThoughtLeft, ThoughtRight string
+
+	// Tail and ThoughtTail lead from the balloon to the cow; they are
+	// substituted for $thoughts in the cow file.
+	Tail, ThoughtTail string
+}
+
+// balloonStyles are the built-in styles. The first one is the default.
+var balloonStyles = []BalloonStyle{
+	{
+		Name:    "ascii",
+		TopLeft: " ", Top: "_",
+		BottomLeft: " ", Bottom: "-",
+		FirstLeft: "/", FirstRight: "\\",
+		Left: "|", Right: "|",
+		LastLeft: "\\", LastRight: "/",
+		SingleLeft: "<", SingleRight: ">",
+		ThoughtLeft: "(", ThoughtRight: ")",
+		Tail: "\\", ThoughtTail: "o",
+	},
+	boxStyle("box", "┌─┐", "│", "└─┘"),
+	boxStyle("rounded", "╭─╮", "│", "╰─╯"),
+	boxStyle("double", "╔═╗", "║", "╚═╝"),
+	boxStyle("heavy", "┏━┓", "┃", "┗━┛"),
+}
+
+// boxStyle returns a style that draws a closed box with the given corners
+// and edges.
+func boxStyle(name, top, side, bottom string) BalloonStyle {
+	t, b := []rune(top), []rune(bottom)
+	return BalloonStyle{
+		Name:    name,
+		TopLeft: string(t[0]), Top: string(t[1]), TopRight: string(t[2]),
+		BottomLeft: string(b[0]), Bottom: string(b[1]), BottomRight: string(b[2]),
+		FirstLeft: side, FirstRight: side,
+		Left: side, Right: side,
+		LastLeft: side, LastRight: side,
+		SingleLeft: side, SingleRight: side,
+		ThoughtLeft: side, ThoughtRight: side,
+		Tail: "\\", ThoughtTail: "o",
+	}
+}
+
+// BalloonStyleNames returns the names of the built-in balloon styles.
+func BalloonStyleNames() []string {
+	names := make([]string, len(balloonStyles))
+	for i, s := range balloonStyles {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// ParseBalloonStyle returns the built-in balloon style with the given name.
+func ParseBalloonStyle(name string) (BalloonStyle, error) {
+	for _, s := range balloonStyles {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return balloonStyles[0], fmt.Errorf("unknown balloon style %q", name)
+}
+
+// edges returns the left and right edge of line i of an n-line balloon.
+func (s *BalloonStyle) edges(i, n int, think bool) (left, right string) {
+	switch {
+	case think:
+		return s.ThoughtLeft, s.ThoughtRight
+	case n == 1:
+		return s.SingleLeft, s.SingleRight
+	case i == 0:
+		return s.FirstLeft, s.FirstRight
+	case i == n-1:
+		return s.LastLeft, s.LastRight
+	default:
+		return s.Left, s.Right
+	}
+}
+
+// Validate checks that the style draws an aligned balloon: the top and
+// bottom edges and the tails are one column wide, and the glyphs on each
+// side of the text are as wide as each other. The right corners may be
+// left empty, as ascii does.
+func (s *BalloonStyle) Validate() error {
+	for _, g := range []struct{ key, glyph string }{
+		{"top", s.Top}, {"bottom", s.Bottom}, {"tail", s.Tail}, {"thought-tail", s.ThoughtTail},
+	} {
+		if w := stringWidth(g.glyph); w != 1 {
+			return fmt.Errorf("%s %q must be 1 column wide, got %d", g.key, g.glyph, w)
+		}
+	}
+
+	sides := [][]string{
+		{s.TopLeft, s.BottomLeft, s.FirstLeft, s.Left, s.LastLeft, s.SingleLeft, s.ThoughtLeft},
+		{s.FirstRight, s.Right, s.LastRight, s.SingleRight, s.ThoughtRight},
+	}
+	for _, glyphs := range sides {
+		for _, g := range glyphs[1:] {
+			if stringWidth(g) != stringWidth(glyphs[0]) {
+				return fmt.Errorf("edges %q and %q differ in width", glyphs[0], g)
+			}
+		}
+	}
+	for _, g := range []string{s.TopRight, s.BottomRight} {
+		if g != "" && stringWidth(g) != stringWidth(s.FirstRight) {
+			return fmt.Errorf("edges %q and %q differ in width", s.FirstRight, g)
+		}
+	}
+	return nil
+}
+
+// LoadBalloonStyle reads a balloon style from a file; see ReadBalloonStyle
+// for the format. The style is named after the file unless it sets a name.
+func LoadBalloonStyle(path string) (BalloonStyle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return BalloonStyle{}, fmt.Errorf("failed to open balloon style: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	style, err := ReadBalloonStyle(f)
+	if err != nil {
+		return BalloonStyle{}, fmt.Errorf("balloon style %q: %w", path, err)
+	}
+	if style.Name == "" {
+		style.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return style, nil
+}
+
+// ReadBalloonStyle reads a balloon style from r. The input has one
+// "key = value" pair per line; blank lines and lines starting with '#' are
+// ignored, and a value may be double-quoted to keep surrounding spaces:
+//
+//	# rounded corners with a heavy frame
+//	base = rounded
+//	left = ┃
+//	right = ┃
+//	top-left = " "
+//
+// The style starts as a copy of the built-in style named by base, ascii by
+// default. "left" and "right" set the edges of every text line; the keys
+// first-left, last-left, single-left, thought-left and their -right
+// counterparts then override single lines. The remaining keys are name,
+// top-left, top, top-right, bottom-left, bottom, bottom-right, tail and
+// thought-tail.
+func ReadBalloonStyle(r io.Reader) (BalloonStyle, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return BalloonStyle{}, fmt.Errorf("line %d: missing '='", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			v, err := strconv.Unquote(value)
+			if err != nil {
+				return BalloonStyle{}, fmt.Errorf("line %d: invalid quoted value %s", line, value)
+			}
+			value = v
+		}
+		if key != "name" && key != "base" && styleGlyphs(&BalloonStyle{})[key] == nil {
+			return BalloonStyle{}, fmt.Errorf("line %d: unknown key %q", line, key)
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return BalloonStyle{}, err
+	}
+
+	base := "ascii"
+	if v, ok := values["base"]; ok {
+		base = v
+	}
+	style, err := ParseBalloonStyle(base)
+	if err != nil {
+		return BalloonStyle{}, err
+	}
+	style.Name = values["name"]
+
+	glyphs := styleGlyphs(&style)
+	for _, side := range []string{"left", "right"} {
+		if v, ok := values[side]; ok {
+			for _, key := range []string{"first-", "", "last-", "single-", "thought-"} {
+				*glyphs[key+side] = v
+			}
+		}
+	}
+	for key, v := range values {
+		if g := glyphs[key]; g != nil && key != "left" && key != "right" {
+			*g = v
+		}
+	}
+
+	if err := style.Validate(); err != nil {
+		return BalloonStyle{}, err
+	}
+	return style, nil
+}
+
+// styleGlyphs maps the keys of a balloon style file to the fields of s.
+func styleGlyphs(s *BalloonStyle) map[string]*string {
+	return map[string]*string{
+		"top-left":      &s.TopLeft,
+		"top":           &s.Top,
+		"top-right":     &s.TopRight,
+		"bottom-left":   &s.BottomLeft,
+		"bottom":        &s.Bottom,
+		"bottom-right":  &s.BottomRight,
+		"first-left":    &s.FirstLeft,
+		"first-right":   &s.FirstRight,
+		"left":          &s.Left,
+		"right":         &s.Right,
+		"last-left":     &s.LastLeft,
+		"last-right":    &s.LastRight,
+		"single-left":   &s.SingleLeft,
+		"single-right":  &s.SingleRight,
+		"thought-left":  &s.ThoughtLeft,
+		"thought-right": &s.ThoughtRight,
+		"tail":          &s.Tail,
+		"thought-tail":  &s.ThoughtTail,
+	}
+}
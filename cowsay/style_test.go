@@ -0,0 +1,166 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/cowsay"
+)
+
+func TestParseBalloonStyle(t *testing.T) {
+	for _, name := range cowsay.BalloonStyleNames() {
+		t.Run(name, func(t *testing.T) {
+			style, err := cowsay.ParseBalloonStyle(name)
+			if err != nil {
+				t.Fatalf("ParseBalloonStyle(%q) unexpected error: %v", name, err)
+			}
+			if style.Name != name {
+				t.Errorf("ParseBalloonStyle(%q).Name = %q", name, style.Name)
+			}
+			if err := style.Validate(); err != nil {
+				t.Errorf("ParseBalloonStyle(%q).Validate() = %v", name, err)
+			}
+		})
+	}
+
+	if _, err := cowsay.ParseBalloonStyle("fancy"); err == nil {
+		t.Errorf("ParseBalloonStyle(%q) expected error but got none", "fancy")
+	}
+}
+
+func TestReadBalloonStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		check   func(s cowsay.BalloonStyle) bool
+		wantErr string
+	}{
+		{
+			name:  "empty file is ascii",
+			input: "",
+			check: func(s cowsay.BalloonStyle) bool {
+				return s.Top == "_" && s.SingleLeft == "<" && s.Tail == "\\"
+			},
+		},
+		{
+			name:  "base and overrides",
+			input: "# comment\n\nname = mine\nbase = rounded\ntail = *\n",
+			check: func(s cowsay.BalloonStyle) bool {
+				return s.Name == "mine" && s.TopLeft == "╭" && s.Tail == "*"
+			},
+		},
+		{
+			name:  "left sets every text line",
+			input: "left = [\nright = ]\nsingle-left = {\n",
+			check: func(s cowsay.BalloonStyle) bool {
+				return s.FirstLeft == "[" && s.Left == "[" && s.LastLeft == "[" &&
+					s.ThoughtLeft == "[" && s.SingleLeft == "{" && s.LastRight == "]"
+			},
+		},
+		{
+			name:  "quoted values",
+			input: `base = box` + "\n" + `top-right = " "` + "\n",
+			check: func(s cowsay.BalloonStyle) bool {
+				return s.TopRight == " "
+			},
+		},
+		{
+			name:    "missing equals",
+			input:   "top _\n",
+			wantErr: "line 1: missing '='",
+		},
+		{
+			name:    "unknown key",
+			input:   "\ncorner = +\n",
+			wantErr: `line 2: unknown key "corner"`,
+		},
+		{
+			name:    "unknown base",
+			input:   "base = fancy\n",
+			wantErr: `unknown balloon style "fancy"`,
+		},
+		{
+			name:    "wide edge",
+			input:   "top = ==\n",
+			wantErr: `top "==" must be 1 column wide, got 2`,
+		},
+		{
+			name:    "misaligned edges",
+			input:   "left = ||\n",
+			wantErr: `edges " " and "||" differ in width`,
+		},
+		{
+			name:    "misaligned corner",
+			input:   "base = box\nbottom-right = ┘┘\n",
+			wantErr: `edges "│" and "┘┘" differ in width`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			style, err := cowsay.ReadBalloonStyle(strings.NewReader(tc.input))
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("ReadBalloonStyle(%q) error = %v, want %q", tc.input, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadBalloonStyle(%q) unexpected error: %v", tc.input, err)
+			}
+			if !tc.check(style) {
+				t.Errorf("ReadBalloonStyle(%q) = %+v", tc.input, style)
+			}
+		})
+	}
+}
+
+func TestLoadBalloonStyle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slack.style")
+	if err := os.WriteFile(path, []byte("base = double\n"), 0o644); err != nil {
+		t.Fatalf("failed to write style: %v", err)
+	}
+
+	style, err := cowsay.LoadBalloonStyle(path)
+	if err != nil {
+		t.Fatalf("LoadBalloonStyle() unexpected error: %v", err)
+	}
+	if style.Name != "slack" || style.Left != "║" {
+		t.Errorf("LoadBalloonStyle() = %+v", style)
+	}
+
+	c := cowsay.NewCow("default", "", cowsay.InBinary)
+	c.Style = style
+	got, err := c.Render("Hi")
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	want := "╔════╗\n║ Hi ║\n╚════╝\n        \\   ^__^"
+	if !strings.HasPrefix(string(got), want) {
+		t.Errorf("Render() = %q, want prefix %q", got, want)
+	}
+}
@@ -0,0 +1,322 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// heredoc is the art section of a .cow file.
+type heredoc struct {
+	body        []byte
+	interpolate bool // false for single-quoted terminators (<<'EOC')
+	line        int  // line number of the first body line
+}
+
+// findHeredoc locates the "$the_cow = <<EOC;" heredoc in a .cow file. The
+// terminator may be bare, double-quoted or single-quoted, and the body ends
+// at the first line consisting only of the terminator. If data contains no
+// heredoc at all, found is false.
+func findHeredoc(data []byte) (doc heredoc, found bool) {
+	pos := bytes.Index(data, []byte("<<"))
+	if pos < 0 {
+		return heredoc{}, false
+	}
+
+	rest := data[pos+2:]
+	quote := byte(0)
+	if len(rest) > 0 && (rest[0] == '"' || rest[0] == '\'') {
+		quote = rest[0]
+		rest = rest[1:]
+	}
+	n := 0
+	for n < len(rest) && isIdentByte(rest[n]) {
+		n++
+	}
+	if n == 0 {
+		return heredoc{}, false
+	}
+	terminator := rest[:n]
+
+	// the body starts on the line after the heredoc operator
+	nl := bytes.IndexByte(data[pos:], '\n')
+	if nl < 0 {
+		return heredoc{}, false
+	}
+	start := pos + nl + 1
+	doc = heredoc{
+		interpolate: quote != '\'',
+		line:        bytes.Count(data[:start], []byte("\n")) + 1,
+	}
+
+	body := data[start:]
+	for off := 0; off < len(body); {
+		end := bytes.IndexByte(body[off:], '\n')
+		if end < 0 {
+			end = len(body) - off
+		}
+		line := bytes.TrimRight(body[off:off+end], "\r")
+		if bytes.Equal(line, terminator) {
+			doc.body = body[:off]
+			return doc, true
+		}
+		off += end + 1
+	}
+
+	// unterminated heredoc: use everything up to the end of the file
+	doc.body = body
+	return doc, true
+}
+
+// varSpan is where the value of a variable ended up in interpolated text.
+type varSpan struct {
+	start, end int
+	name       string
+}
+
+// interpolate expands a heredoc body the way Perl expands a double-quoted
+// string: backslash escapes are decoded and scalar variables ($name or
+// ${name}) are replaced by their value in vars. The spans of the values are
+// returned in order. An unknown variable or a malformed escape is reported
+// together with its line number.
+func interpolate(body []byte, vars map[string]string, firstLine int) ([]byte, []varSpan, error) {
+	var out bytes.Buffer
+	var spans []varSpan
+	line := firstLine
+
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		switch {
+		case ch == '\n':
+			line++
+			out.WriteByte(ch)
+		case ch == '\\' && i+1 < len(body):
+			n, err := unescape(body[i+1:], &out)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if body[i+1] == '\n' {
+				line++
+			}
+			i += n
+		case ch == '$':
+			name, n := scanVariable(body[i+1:])
+			if n == 0 {
+				out.WriteByte(ch)
+				continue
+			}
+			value, ok := vars[name]
+			if !ok {
+				return nil, nil, fmt.Errorf("line %d: unknown variable $%s", line, name)
+			}
+			start := out.Len()
+			out.WriteString(value)
+			spans = append(spans, varSpan{start: start, end: out.Len(), name: name})
+			i += n
+		default:
+			out.WriteByte(ch)
+		}
+	}
+
+	return out.Bytes(), spans, nil
+}
+
+// simpleEscapes maps the single-character Perl escapes to their values.
+var simpleEscapes = map[byte]byte{
+	'a': '\a',
+	'e': 0x1b,
+	'f': '\f',
+	'n': '\n',
+	'r': '\r',
+	't': '\t',
+}
+
+// unescape decodes the escape sequence following a backslash, writes the
+// result to out and returns the number of bytes consumed after the
+// backslash. Like Perl, a backslash before any other character yields that
+// character, so \\, \@ and \$ become \, @ and $.
+func unescape(b []byte, out *bytes.Buffer) (int, error) {
+	ch := b[0]
+	if v, ok := simpleEscapes[ch]; ok {
+		out.WriteByte(v)
+		return 1, nil
+	}
+
+	switch ch {
+	case 'x':
+		// \x{263A} or up to two hex digits (\x1b)
+		if len(b) > 1 && b[1] == '{' {
+			end := bytes.IndexByte(b, '}')
+			if end < 0 {
+				return 0, errors.New(`unterminated \x{...} escape`)
+			}
+			r, err := parseCodePoint(b[2:end], 16)
+			if err != nil {
+				return 0, fmt.Errorf(`invalid \x{...} escape: %w`, err)
+			}
+			out.WriteRune(r)
+			return end + 1, nil
+		}
+		n := 1
+		for n < len(b) && n < 3 && isHexByte(b[n]) {
+			n++
+		}
+		r, _ := parseCodePoint(b[1:n], 16)
+		out.WriteRune(r)
+		return n, nil
+	case 'N':
+		// \N{U+263A}
+		if len(b) < 4 || b[1] != '{' || b[2] != 'U' || b[3] != '+' {
+			return 0, errors.New(`only \N{U+XXXX} escapes are supported`)
+		}
+		end := bytes.IndexByte(b, '}')
+		if end < 0 {
+			return 0, errors.New(`unterminated \N{...} escape`)
+		}
+		r, err := parseCodePoint(b[4:end], 16)
+		if err != nil {
+			return 0, fmt.Errorf(`invalid \N{...} escape: %w`, err)
+		}
+		out.WriteRune(r)
+		return end + 1, nil
+	case 'o':
+		// \o{033}
+		if len(b) < 2 || b[1] != '{' {
+			return 0, errors.New(`missing braces on \o{...} escape`)
+		}
+		end := bytes.IndexByte(b, '}')
+		if end < 0 {
+			return 0, errors.New(`unterminated \o{...} escape`)
+		}
+		r, err := parseCodePoint(b[2:end], 8)
+		if err != nil {
+			return 0, fmt.Errorf(`invalid \o{...} escape: %w`, err)
+		}
+		out.WriteRune(r)
+		return end + 1, nil
+	case 'c':
+		// \c[ is ESC, \c@ is NUL, \cA is ^A, ...
+		if len(b) < 2 || b[1] >= utf8.RuneSelf {
+			return 0, errors.New(`missing control character after \c`)
+		}
+		c := b[1]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		out.WriteByte(c ^ 0x40)
+		return 2, nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		n := 1
+		for n < len(b) && n < 3 && b[n] >= '0' && b[n] <= '7' {
+			n++
+		}
+		r, _ := parseCodePoint(b[:n], 8)
+		out.WriteRune(r)
+		return n, nil
+	}
+
+	// any other character stands for itself
+	_, size := utf8.DecodeRune(b)
+	out.Write(b[:size])
+	return size, nil
+}
+
+// parseCodePoint parses the digits of a numeric escape. An empty digit
+// string is the NUL character, as in Perl.
+func parseCodePoint(digits []byte, base int) (rune, error) {
+	if len(digits) == 0 {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(string(digits), base, 32)
+	if err != nil {
+		return 0, err
+	}
+	r := rune(v)
+	if !utf8.ValidRune(r) {
+		return 0, fmt.Errorf("code point %#x out of range", v)
+	}
+	return r, nil
+}
+
+func isHexByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// scanVariable reads a variable name following a '$'. It returns the name
+// and the number of bytes consumed, or 0 if no variable starts here.
+func scanVariable(b []byte) (string, int) {
+	if len(b) > 0 && b[0] == '{' {
+		end := bytes.IndexByte(b, '}')
+		if end <= 1 {
+			return "", 0
+		}
+		name := b[1:end]
+		for _, c := range name {
+			if !isIdentByte(c) {
+				return "", 0
+			}
+		}
+		return string(name), end + 1
+	}
+
+	n := 0
+	for n < len(b) && isIdentByte(b[n]) {
+		n++
+	}
+	if n == 0 || (b[0] >= '0' && b[0] <= '9') {
+		return "", 0
+	}
+	return string(b[:n]), n
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// parseCow extracts the cow art from the contents of a .cow file and
+// substitutes the template variables, returning where their values went.
+// Files without a heredoc are used verbatim.
+func parseCow(data []byte, vars map[string]string) ([]byte, []varSpan, error) {
+	art := data
+	var spans []varSpan
+	if doc, ok := findHeredoc(data); ok {
+		art = doc.body
+		if doc.interpolate {
+			var err error
+			art, spans, err = interpolate(doc.body, vars, doc.line)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid cow file: %w", err)
+			}
+		}
+	}
+
+	art = bytes.TrimRight(art, "\n")
+	if len(art) == 0 {
+		return nil, nil, errors.New("invalid cow file: no art found")
+	}
+	return art, spans, nil
+}
@@ -0,0 +1,148 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cowsay
+
+import (
+	"testing"
+)
+
+func TestParseCow(t *testing.T) {
+	vars := map[string]string{
+		"eyes":     "oo",
+		"tongue":   "U ",
+		"thoughts": "\\",
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantArt string
+		hasErr  bool
+	}{
+		{
+			name:    "substitutes variables",
+			data:    "$the_cow = <<EOC;\n $thoughts ($eyes)\n  $tongue\nEOC\n",
+			wantArt: " \\ (oo)\n  U ",
+		},
+		{
+			name:    "braced variables",
+			data:    "$the_cow = <<EOC;\n${eyes}x\nEOC\n",
+			wantArt: "oox",
+		},
+		{
+			name:    "double-quoted terminator",
+			data:    "##\n## comment\n##\n$the_cow = <<\"EOC\";\n$eyes\nEOC\n",
+			wantArt: "oo",
+		},
+		{
+			name:    "single-quoted terminator does not interpolate",
+			data:    "$the_cow = <<'EOC';\n$eyes\nEOC\n",
+			wantArt: "$eyes",
+		},
+		{
+			name:    "terminator must be on its own line",
+			data:    "$the_cow = <<EOC;\nEOCX $eyes\nEOC\n",
+			wantArt: "EOCX oo",
+		},
+		{
+			name:    "escaped dollar is not a variable",
+			data:    "$the_cow = <<EOC;\n\\$eyes $ 1\nEOC\n",
+			wantArt: "$eyes $ 1",
+		},
+		{
+			name:    "perl escapes",
+			data:    "$the_cow = <<EOC;\n\\\\ \\@ \\_ \\\"\nEOC\n",
+			wantArt: "\\ @ _ \"",
+		},
+		{
+			name:    "control escapes",
+			data:    "$the_cow = <<EOC;\n\\e[1m\\t\\033\\x1b\\c[\\x{1b}\nEOC\n",
+			wantArt: "\x1b[1m\t\x1b\x1b\x1b\x1b",
+		},
+		{
+			name:    "unicode escapes",
+			data:    "$the_cow = <<EOC;\n\\x{1F600}\\N{U+263A}\\o{101}\nEOC\n",
+			wantArt: "\U0001F600\u263aA",
+		},
+		{
+			name:    "single-quoted terminator keeps escapes",
+			data:    "$the_cow = <<'EOC';\n\\\\\nEOC\n",
+			wantArt: "\\\\",
+		},
+		{
+			name:   "unterminated hex escape",
+			data:   "$the_cow = <<EOC;\n\\x{1F600\nEOC\n",
+			hasErr: true,
+		},
+		{
+			name:   "out of range code point",
+			data:   "$the_cow = <<EOC;\n\\x{110000}\nEOC\n",
+			hasErr: true,
+		},
+		{
+			name:    "no heredoc uses the whole file",
+			data:    "  (oo)\n",
+			wantArt: "  (oo)",
+		},
+		{
+			name:   "unknown variable",
+			data:   "$the_cow = <<EOC;\n\n $horns\nEOC\n",
+			hasErr: true,
+		},
+		{
+			name:   "empty art",
+			data:   "$the_cow = <<EOC;\nEOC\n",
+			hasErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _, err := parseCow([]byte(tc.data), vars)
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("parseCow(%q) expected error but got none", tc.data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCow(%q) unexpected error: %v", tc.data, err)
+			}
+			if tc.wantArt != string(got) {
+				t.Fatalf("parseCow(%q) = %q, want %q", tc.data, got, tc.wantArt)
+			}
+		})
+	}
+}
+
+func TestParseCowUnknownVariableLine(t *testing.T) {
+	data := "##\n$the_cow = <<EOC;\nfirst\n $horns\nEOC\n"
+	_, _, err := parseCow([]byte(data), map[string]string{})
+	if err == nil {
+		t.Fatal("expected error but got none")
+	}
+	want := "invalid cow file: line 4: unknown variable $horns"
+	if err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+}
@@ -22,31 +22,28 @@
 
 package decoration
 
-import (
-	"bytes"
-	"fmt"
-	"unicode"
-	"unicode/utf8"
-)
+import "fmt"
 
 // Blob applies "blob" style decoration to the input text.
 func Blob(input []byte) []byte {
-	var buf bytes.Buffer
+	return style{pen: pen{attrs: AttrBold}}.Decorate(input)
+}
+
+// blob is the "blob" decorator, also registered as "bold". It takes no
+// parameters, and leaves the input unchanged with ProfileNone.
+type blob struct{}
 
-	for len(input) > 0 {
-		rn, size := utf8.DecodeRune(input)
-		if rn == '\n' {
-			buf.WriteRune(rn)
-			input = input[size:]
-			continue
-		}
-		if unicode.IsSpace(rn) {
-			buf.WriteRune(rn)
-		} else {
-			fmt.Fprintf(&buf, "\x1b[1m%c\x1b[0m", rn)
-		}
-		input = input[size:]
+func newBlob(params Params, profile Profile) (Decorator, error) {
+	for key := range params {
+		return nil, fmt.Errorf("unknown parameter %q", key)
 	}
+	if profile == ProfileNone {
+		return Pipeline{}, nil
+	}
+	return blob{}, nil
+}
 
-	return buf.Bytes()
+// Decorate implements Decorator.
+func (blob) Decorate(input []byte) []byte {
+	return Blob(input)
 }
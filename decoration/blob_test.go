@@ -47,7 +47,17 @@ func TestBlob(t *testing.T) {
 		{
 			name:    "unicode full-width runes",
 			msg:     "你好\n",
-			wantMsg: "\x1b[1m你\x1b[0m\x1b[1m好\x1b[0m\n",
+			wantMsg: "\x1b[1m你好\x1b[0m\n",
+		},
+		{
+			name:    "escape sequences kept",
+			msg:     "\x1b[31mx\x1b[0m\n",
+			wantMsg: "\x1b[31m\x1b[1mx\x1b[0m\n",
+		},
+		{
+			name:    "colors of the input kept past spaces",
+			msg:     "\x1b[31mred text\x1b[0m\n",
+			wantMsg: "\x1b[31m\x1b[1mred\x1b[22m \x1b[1mtext\x1b[0m\n",
 		},
 		{
 			name:    "empty input",
@@ -63,3 +73,29 @@ func TestBlob(t *testing.T) {
 		}
 	}
 }
+
+func TestBlobProfile(t *testing.T) {
+	msg := "x y\n"
+	tests := []struct {
+		spec    string
+		profile decoration.Profile
+		wantMsg string
+	}{
+		{"blob", decoration.Profile16, "\x1b[1mx\x1b[0m \x1b[1my\x1b[0m\n"},
+		{"blob", decoration.ProfileNone, msg},
+		{"bold", decoration.ProfileNone, msg},
+		{"style:attrs=bold+italic:fg=red", decoration.ProfileNone, msg},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec+"/"+tc.profile.String(), func(t *testing.T) {
+			p, err := decoration.Parse(tc.spec, tc.profile)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.spec, err)
+			}
+			if got := p.Decorate([]byte(msg)); tc.wantMsg != string(got) {
+				t.Fatalf("%s with profile %v on %q = %q, want %q", tc.spec, tc.profile, msg, got, tc.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color is a 24-bit RGB color.
+type Color struct {
+	R, G, B uint8
+}
+
+// ParseHexColor parses a color written as #rrggbb or #rgb. The leading #
+// may be left out.
+func ParseHexColor(s string) (Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return Color{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	return Color{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v)}, nil
+}
+
+// Foreground returns the SGR sequence that sets the foreground to c, or
+// to the nearest color the profile has. It returns "" for ProfileNone.
+func (p Profile) Foreground(c Color) string {
+	return pen{fg: p.quantize(c)}.sequence()
+}
+
+// Background is like Foreground but sets the background color.
+func (p Profile) Background(c Color) string {
+	return pen{bg: p.quantize(c)}.sequence()
+}
+
+// quantize returns the color of the profile nearest to c, or no color for
+// ProfileNone.
+func (p Profile) quantize(c Color) StyleColor {
+	switch p {
+	case ProfileTrueColor:
+		return RGBColor(c)
+	case Profile256:
+		return StyleColor{kind: color256, index: uint8(index256(c))}
+	case Profile16:
+		return ANSIColor(index16(c))
+	}
+	return StyleColor{}
+}
+
+// ansi16 are the xterm defaults for the 16 ANSI colors.
+var ansi16 = [16]Color{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the channel values of the 6×6×6 color cube in the xterm
+// 256-color palette.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// index16 returns the ANSI color nearest to c.
+func index16(c Color) int {
+	best, bestDist := 0, -1
+	for i, a := range ansi16 {
+		if d := distance(c, a); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// index256 returns the entry of the xterm 256-color palette nearest to c,
+// choosing between the color cube (16–231) and the gray ramp (232–255).
+// The first 16 entries are left out as terminals often redefine them.
+func index256(c Color) int {
+	r, g, b := cubeIndex(c.R), cubeIndex(c.G), cubeIndex(c.B)
+	cube := Color{cubeLevels[r], cubeLevels[g], cubeLevels[b]}
+
+	// the gray ramp runs from 8 to 238 in steps of 10
+	avg := (int(c.R) + int(c.G) + int(c.B)) / 3
+	step := min(max((avg-3)/10, 0), 23)
+	level := uint8(8 + 10*step)
+	gray := Color{level, level, level}
+
+	if distance(c, gray) < distance(c, cube) {
+		return 232 + step
+	}
+	return 16 + 36*r + 6*g + b
+}
+
+// cubeIndex returns the index of the cube level nearest to v.
+func cubeIndex(v uint8) int {
+	switch {
+	case v < 48:
+		return 0
+	case v < 115:
+		return 1
+	}
+	return (int(v) - 35) / 40
+}
+
+// distance returns the squared Euclidean distance between two colors.
+func distance(a, b Color) int {
+	dr := int(a.R) - int(b.R)
+	dg := int(a.G) - int(b.G)
+	db := int(a.B) - int(b.B)
+	return dr*dr + dg*dg + db*db
+}
+
+// oklab is a color in the OKLab color space, in which equal distances look
+// roughly equally different, so blends between colors look even.
+type oklab struct {
+	l, a, b float64
+}
+
+// toOKLab converts c from sRGB to OKLab.
+func toOKLab(c Color) oklab {
+	r, g, b := toLinear(c.R), toLinear(c.G), toLinear(c.B)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return oklab{
+		l: 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		a: 1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		b: 0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+// color converts c back to sRGB, clipping colors outside its gamut.
+func (c oklab) color() Color {
+	l := c.l + 0.3963377774*c.a + 0.2158037573*c.b
+	m := c.l - 0.1055613458*c.a - 0.0638541728*c.b
+	s := c.l - 0.0894841775*c.a - 1.2914855480*c.b
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return Color{
+		R: fromLinear(+4.0767416621*l - 3.3077115913*m + 0.2309699292*s),
+		G: fromLinear(-1.2684380046*l + 2.6097574011*m - 0.3413193965*s),
+		B: fromLinear(-0.0041960863*l - 0.7034186147*m + 1.7076147010*s),
+	}
+}
+
+// lerp blends from c to d; t = 0 gives c and t = 1 gives d.
+func (c oklab) lerp(d oklab, t float64) oklab {
+	return oklab{
+		l: c.l + (d.l-c.l)*t,
+		a: c.a + (d.a-c.a)*t,
+		b: c.b + (d.b-c.b)*t,
+	}
+}
+
+// toLinear removes the sRGB gamma from a channel.
+func toLinear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// fromLinear applies the sRGB gamma to a linear channel and rounds it.
+func fromLinear(c float64) uint8 {
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(min(max(c, 0), 1) * 255))
+}
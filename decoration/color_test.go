@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func TestForeground(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile decoration.Profile
+		color   decoration.Color
+		want    string
+	}{
+		{"truecolor", decoration.ProfileTrueColor, decoration.Color{R: 171, G: 209, B: 2}, "\x1b[38;2;171;209;2m"},
+		{"256 cube corner", decoration.Profile256, decoration.Color{R: 255, G: 0, B: 0}, "\x1b[38;5;196m"},
+		{"256 cube", decoration.Profile256, decoration.Color{R: 171, G: 209, B: 2}, "\x1b[38;5;148m"},
+		{"256 gray", decoration.Profile256, decoration.Color{R: 128, G: 128, B: 130}, "\x1b[38;5;244m"},
+		{"256 black", decoration.Profile256, decoration.Color{}, "\x1b[38;5;16m"},
+		{"16 red", decoration.Profile16, decoration.Color{R: 200, G: 10, B: 10}, "\x1b[31m"},
+		{"16 bright blue", decoration.Profile16, decoration.Color{R: 90, G: 90, B: 250}, "\x1b[94m"},
+		{"16 white", decoration.Profile16, decoration.Color{R: 250, G: 250, B: 250}, "\x1b[97m"},
+		{"none", decoration.ProfileNone, decoration.Color{R: 255}, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.profile.Foreground(tc.color); got != tc.want {
+				t.Fatalf("%v.Foreground(%v) = %q, want %q", tc.profile, tc.color, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackground(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile decoration.Profile
+		color   decoration.Color
+		want    string
+	}{
+		{"truecolor", decoration.ProfileTrueColor, decoration.Color{R: 171, G: 209, B: 2}, "\x1b[48;2;171;209;2m"},
+		{"256", decoration.Profile256, decoration.Color{R: 255, G: 0, B: 0}, "\x1b[48;5;196m"},
+		{"16 red", decoration.Profile16, decoration.Color{R: 200, G: 10, B: 10}, "\x1b[41m"},
+		{"16 bright blue", decoration.Profile16, decoration.Color{R: 90, G: 90, B: 250}, "\x1b[104m"},
+		{"none", decoration.ProfileNone, decoration.Color{R: 255}, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.profile.Background(tc.color); got != tc.want {
+				t.Fatalf("%v.Background(%v) = %q, want %q", tc.profile, tc.color, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseHexColor(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    decoration.Color
+		wantErr bool
+	}{
+		{s: "#ff8000", want: decoration.Color{R: 255, G: 128}},
+		{s: "1e90FF", want: decoration.Color{R: 30, G: 144, B: 255}},
+		{s: "#f80", want: decoration.Color{R: 255, G: 136}},
+		{s: "#ff80", wantErr: true},
+		{s: "#gg0000", wantErr: true},
+		{s: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := decoration.ParseHexColor(tc.s)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseHexColor(%q) = %v, want error", tc.s, got)
+				}
+				return
+			}
+			if err != nil || got != tc.want {
+				t.Fatalf("ParseHexColor(%q) = %v, %v, want %v", tc.s, got, err, tc.want)
+			}
+		})
+	}
+}
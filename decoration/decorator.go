@@ -0,0 +1,182 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/xogas/cowsay-go/cowsay"
+)
+
+// Decorator transforms rendered cowsay output, typically by wrapping runes
+// in terminal escape sequences.
+type Decorator interface {
+	Decorate(input []byte) []byte
+}
+
+// DecoratorFunc adapts an ordinary function to a Decorator.
+type DecoratorFunc func(input []byte) []byte
+
+// Decorate calls f(input).
+func (f DecoratorFunc) Decorate(input []byte) []byte {
+	return f(input)
+}
+
+// Params holds the key=value parameters given to a decorator, as in
+// "rainbow:freq=0.2".
+type Params map[string]string
+
+// Factory builds a decorator from its parameters. It should reject
+// parameters it does not know. Decorators that add colors should limit
+// them to profile, for example with Profile.Foreground.
+type Factory func(params Params, profile Profile) (Decorator, error)
+
+// Pipeline applies decorators one after another, in order.
+type Pipeline []Decorator
+
+// Decorate implements Decorator.
+func (p Pipeline) Decorate(input []byte) []byte {
+	for _, d := range p {
+		input = d.Decorate(input)
+	}
+	return input
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"rainbow":  newRainbow,
+		"blob":     newBlob,
+		"bold":     newBlob,
+		"gradient": newGradient,
+		"palette":  newPaletteDecorator,
+		"style":    newStyle,
+	}
+)
+
+// Register makes a decorator available under name. It panics if name is
+// empty, contains one of the separators ",:=", or is already registered.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" || strings.ContainsAny(name, ",:=") {
+		panic(fmt.Sprintf("decoration: invalid decorator name %q", name))
+	}
+	if factory == nil {
+		panic("decoration: Register factory is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic(fmt.Sprintf("decoration: Register called twice for decorator %q", name))
+	}
+	registry[name] = factory
+}
+
+// Names returns the names of all registered decorators in sorted order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// New returns the registered decorator name built with params for the
+// color profile.
+func New(name string, params Params, profile Profile) (Decorator, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown decorator %q", name)
+	}
+	d, err := factory(params, profile)
+	if err != nil {
+		return nil, fmt.Errorf("decorator %q: %w", name, err)
+	}
+	return d, nil
+}
+
+// Parse builds a pipeline from a comma-separated list of decorators, each
+// optionally followed by colon-separated parameters:
+//
+//	rainbow:freq=0.2,bold
+//
+// The decorators are applied in the order they are listed and limit their
+// colors to profile. Empty entries are ignored. Any decorator takes a
+// region parameter, regions of the cow joined by "+" as in region=text or
+// region=eyes+tongue, which limits it with InRegions.
+func Parse(spec string, profile Profile) (Pipeline, error) {
+	var p Pipeline
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, rest, _ := strings.Cut(entry, ":")
+		params := Params{}
+		if rest != "" {
+			for param := range strings.SplitSeq(rest, ":") {
+				key, value, ok := strings.Cut(param, "=")
+				if !ok || key == "" {
+					return nil, fmt.Errorf("decorator %q: malformed parameter %q", name, param)
+				}
+				if _, dup := params[key]; dup {
+					return nil, fmt.Errorf("decorator %q: duplicate parameter %q", name, key)
+				}
+				params[key] = value
+			}
+		}
+
+		var regions []cowsay.Region
+		if value, ok := params["region"]; ok {
+			delete(params, "region")
+			for region := range strings.SplitSeq(value, "+") {
+				r, err := cowsay.ParseRegions(region)
+				if err != nil {
+					return nil, fmt.Errorf("decorator %q: %w", name, err)
+				}
+				regions = append(regions, r...)
+			}
+		}
+
+		d, err := New(name, params, profile)
+		if err != nil {
+			return nil, err
+		}
+		if regions != nil {
+			d = InRegions(d, regions...)
+		}
+		p = append(p, d)
+	}
+	return p, nil
+}
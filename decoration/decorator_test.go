@@ -0,0 +1,139 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func TestParse(t *testing.T) {
+	msg := []byte("x y\n")
+	tests := []struct {
+		name    string
+		spec    string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "empty spec",
+			spec: "",
+			want: msg,
+		},
+		{
+			name: "single decorator",
+			spec: "rainbow",
+			want: decoration.Rainbow(msg),
+		},
+		{
+			name: "applied in order",
+			spec: "rainbow,bold",
+			want: decoration.Blob(decoration.Rainbow(msg)),
+		},
+		{
+			name: "reverse order",
+			spec: "bold, rainbow",
+			want: decoration.Rainbow(decoration.Blob(msg)),
+		},
+		{
+			name: "default freq",
+			spec: "rainbow:freq=0.35",
+			want: decoration.Rainbow(msg),
+		},
+		{
+			name: "custom freq",
+			spec: "rainbow:freq=1",
+			want: []byte("\x1b[38;2;234;133;15mx\x1b[0m \x1b[38;2;170;3;210my\x1b[0m\n"),
+		},
+		{
+			name:    "unknown decorator",
+			spec:    "sparkle",
+			wantErr: true,
+		},
+		{
+			name:    "unknown parameter",
+			spec:    "rainbow:speed=2",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			spec:    "rainbow:freq=fast",
+			wantErr: true,
+		},
+		{
+			name:    "malformed parameter",
+			spec:    "rainbow:freq",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate parameter",
+			spec:    "rainbow:freq=1:freq=2",
+			wantErr: true,
+		},
+		{
+			name:    "parameters for blob",
+			spec:    "blob:x=1",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := decoration.Parse(tc.spec, decoration.ProfileTrueColor)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) succeeded, want error", tc.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.spec, err)
+			}
+			if got := p.Decorate(msg); !bytes.Equal(got, tc.want) {
+				t.Fatalf("Parse(%q).Decorate(%q) = %q, want %q", tc.spec, msg, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	decoration.Register("test-upper", func(decoration.Params, decoration.Profile) (decoration.Decorator, error) {
+		return decoration.DecoratorFunc(bytes.ToUpper), nil
+	})
+
+	if !slices.Contains(decoration.Names(), "test-upper") {
+		t.Fatalf("Names() = %q, want it to contain %q", decoration.Names(), "test-upper")
+	}
+
+	p, err := decoration.Parse("test-upper,bold", decoration.ProfileTrueColor)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := decoration.Blob([]byte("MOO\n"))
+	if got := p.Decorate([]byte("moo\n")); !bytes.Equal(got, want) {
+		t.Fatalf("Decorate(%q) = %q, want %q", "moo\n", got, want)
+	}
+}
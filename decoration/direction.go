@@ -0,0 +1,68 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import "fmt"
+
+// Direction is the direction in which colors change across the output.
+type Direction int
+
+const (
+	// DirectionDiagonal changes colors along each line and from one line
+	// to the next.
+	DirectionDiagonal Direction = iota
+	// DirectionHorizontal changes colors along each line only, giving
+	// vertical stripes.
+	DirectionHorizontal
+	// DirectionVertical changes colors from one line to the next only,
+	// giving horizontal bands.
+	DirectionVertical
+	// DirectionRadial changes colors from the middle of the output out
+	// to its corners.
+	DirectionRadial
+)
+
+var directionNames = []string{
+	DirectionDiagonal:   "diagonal",
+	DirectionHorizontal: "horizontal",
+	DirectionVertical:   "vertical",
+	DirectionRadial:     "radial",
+}
+
+// String returns the name of the direction.
+func (d Direction) String() string {
+	if d < 0 || int(d) >= len(directionNames) {
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+	return directionNames[d]
+}
+
+// ParseDirection returns the direction with the given name.
+func ParseDirection(name string) (Direction, error) {
+	for i, n := range directionNames {
+		if n == name {
+			return Direction(i), nil
+		}
+	}
+	return DirectionDiagonal, fmt.Errorf("unknown direction %q", name)
+}
@@ -0,0 +1,167 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+	"github.com/xogas/cowsay-go/internal/unitext"
+)
+
+// GradientOptions describes a gradient.
+type GradientOptions struct {
+	// Stops are the colors of the gradient, spaced evenly from its start
+	// to its end. There must be at least two.
+	Stops []Color
+	// Direction is the direction in which the colors change. Radial
+	// gradients run from the middle of the output to its corners.
+	Direction Direction
+}
+
+// NewGradient returns a decorator that colors text with a gradient,
+// limited to the color profile. Colors between the stops are blended in
+// OKLab, so that the blend looks even.
+func NewGradient(opts GradientOptions, profile Profile) (Decorator, error) {
+	if len(opts.Stops) < 2 {
+		return nil, errors.New("at least two colors are needed")
+	}
+	g := gradient{direction: opts.Direction, profile: profile}
+	for _, c := range opts.Stops {
+		g.stops = append(g.stops, toOKLab(c))
+	}
+	return g, nil
+}
+
+// gradient is the "gradient" decorator. Its parameters are stops, hex
+// colors joined by "-" as in stops=#ff0000-#0000ff, and direction.
+type gradient struct {
+	stops     []oklab
+	direction Direction
+	profile   Profile
+}
+
+func newGradient(params Params, profile Profile) (Decorator, error) {
+	var opts GradientOptions
+	for key, value := range params {
+		switch key {
+		case "stops":
+			for hex := range strings.SplitSeq(value, "-") {
+				c, err := ParseHexColor(hex)
+				if err != nil {
+					return nil, err
+				}
+				opts.Stops = append(opts.Stops, c)
+			}
+		case "direction":
+			d, err := ParseDirection(value)
+			if err != nil {
+				return nil, err
+			}
+			opts.Direction = d
+		default:
+			return nil, fmt.Errorf("unknown parameter %q", key)
+		}
+	}
+	return NewGradient(opts, profile)
+}
+
+// at returns the color of the cell in column x of line y, in output that
+// is width columns wide and height lines high.
+func (g gradient) at(x, y, width, height int) Color {
+	fx, fy := fraction(x, width-1), fraction(y, height-1)
+
+	var t float64
+	switch g.direction {
+	case DirectionHorizontal:
+		t = fx
+	case DirectionVertical:
+		t = fy
+	case DirectionRadial:
+		// cells are about twice as high as they are wide
+		cx, cy := float64(width-1)/2, float64(height-1)/2
+		if r := math.Hypot(cx, 2*cy); r > 0 {
+			t = math.Hypot(float64(x)-cx, 2*(float64(y)-cy)) / r
+		}
+	default:
+		t = (fx + fy) / 2
+	}
+	t = min(max(t, 0), 1)
+
+	// find the pair of stops t falls between
+	pos := t * float64(len(g.stops)-1)
+	i := min(int(pos), len(g.stops)-2)
+	return g.stops[i].lerp(g.stops[i+1], pos-float64(i)).color()
+}
+
+// fraction returns n/d, or 0 if d is not positive.
+func fraction(n, d int) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// extent returns the width in columns of the widest line of text and its
+// number of lines, ignoring escape sequences and a final newline.
+func extent(text string) (width, height int) {
+	text = strings.TrimSuffix(text, "\n")
+	for line := range strings.SplitSeq(text, "\n") {
+		w := 0
+		for len(line) > 0 {
+			i := strings.IndexByte(line, ansi.Esc)
+			if i < 0 {
+				w += unitext.Width(line)
+				break
+			}
+			w += unitext.Width(line[:i])
+			line = line[i+ansi.EscapeLen(line[i:]):]
+		}
+		width = max(width, w)
+		height++
+	}
+	return width, height
+}
+
+// Decorate implements Decorator.
+func (g gradient) Decorate(input []byte) []byte {
+	width, height := extent(string(input))
+
+	x, y := 0, 0
+	return decorate(input, func(rn rune, _ string) pen {
+		if rn == '\n' {
+			x, y = 0, y+1
+			return pen{}
+		}
+		var p pen
+		if !unicode.IsSpace(rn) {
+			p = pen{fg: g.profile.quantize(g.at(x, y, width, height))}
+		}
+		x += unitext.RuneWidth(rn)
+		return p
+	})
+}
@@ -0,0 +1,128 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func TestGradient(t *testing.T) {
+	red := decoration.Color{R: 255}
+	green := decoration.Color{G: 255}
+	blue := decoration.Color{B: 255}
+	tests := []struct {
+		name      string
+		stops     []decoration.Color
+		direction decoration.Direction
+		msg       string
+		wantMsg   string
+	}{
+		{
+			name:      "horizontal blends in oklab",
+			stops:     []decoration.Color{red, blue},
+			direction: decoration.DirectionHorizontal,
+			msg:       "abc\n",
+			wantMsg:   "\x1b[38;2;255;0;0ma\x1b[38;2;140;83;162mb\x1b[38;2;0;0;255mc\x1b[0m\n",
+		},
+		{
+			name:      "vertical",
+			stops:     []decoration.Color{red, blue},
+			direction: decoration.DirectionVertical,
+			msg:       "ab\ncd\n",
+			wantMsg:   "\x1b[38;2;255;0;0mab\x1b[0m\n\x1b[38;2;0;0;255mcd\x1b[0m\n",
+		},
+		{
+			name:      "diagonal",
+			stops:     []decoration.Color{red, blue},
+			direction: decoration.DirectionDiagonal,
+			msg:       "ab\ncd",
+			wantMsg:   "\x1b[38;2;255;0;0ma\x1b[38;2;140;83;162mb\x1b[0m\n\x1b[38;2;140;83;162mc\x1b[38;2;0;0;255md\x1b[0m",
+		},
+		{
+			name:      "three stops",
+			stops:     []decoration.Color{red, green, blue},
+			direction: decoration.DirectionHorizontal,
+			msg:       "abc",
+			wantMsg:   "\x1b[38;2;255;0;0ma\x1b[38;2;0;255;0mb\x1b[38;2;0;0;255mc\x1b[0m",
+		},
+		{
+			name:      "radial starts in the middle",
+			stops:     []decoration.Color{red, blue},
+			direction: decoration.DirectionRadial,
+			msg:       "   \n x \n   ",
+			wantMsg:   "   \n \x1b[38;2;255;0;0mx\x1b[0m \n   ",
+		},
+		{
+			name:      "wide runes span two columns",
+			stops:     []decoration.Color{red, blue},
+			direction: decoration.DirectionHorizontal,
+			msg:       "好x",
+			wantMsg:   "\x1b[38;2;255;0;0m好\x1b[38;2;0;0;255mx\x1b[0m",
+		},
+		{
+			name:      "escape sequences kept and not counted",
+			stops:     []decoration.Color{red, blue},
+			direction: decoration.DirectionHorizontal,
+			msg:       "\x1b[1ma\x1b[0mb",
+			wantMsg:   "\x1b[1m\x1b[38;2;255;0;0ma\x1b[0m\x1b[38;2;0;0;255mb\x1b[0m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := decoration.GradientOptions{Stops: tc.stops, Direction: tc.direction}
+			g, err := decoration.NewGradient(opts, decoration.ProfileTrueColor)
+			if err != nil {
+				t.Fatalf("NewGradient failed: %v", err)
+			}
+			if got := g.Decorate([]byte(tc.msg)); tc.wantMsg != string(got) {
+				t.Fatalf("gradient %v on %q = %q, want %q", tc.direction, tc.msg, got, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestGradientParams(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"gradient:stops=#ff0000-#0000ff", false},
+		{"gradient:stops=f00-0f0-00f:direction=radial", false},
+		{"gradient", true},
+		{"gradient:stops=#ff0000", true},
+		{"gradient:stops=#ff0000-blue", true},
+		{"gradient:stops=#ff0000-#0000ff:direction=up", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			_, err := decoration.Parse(tc.spec, decoration.ProfileTrueColor)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tc.spec, err, tc.wantErr)
+			}
+		})
+	}
+}
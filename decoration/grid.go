@@ -0,0 +1,172 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xogas/cowsay-go/cowsay"
+	"github.com/xogas/cowsay-go/internal/ansi"
+)
+
+// regional is a decorator limited to some regions of a rendered cow.
+type regional struct {
+	Decorator
+	regions []cowsay.Region
+}
+
+// InRegions limits d to the cells of a rendered cow in the given regions
+// when it is applied with DecorateGrid. Plain text has no regions, so its
+// Decorate method applies d to everything.
+func InRegions(d Decorator, regions ...cowsay.Region) Decorator {
+	return regional{Decorator: d, regions: regions}
+}
+
+// DecorateGrid applies d to a rendered cow and returns the decorated grid.
+// Decorators limited by InRegions, also within a Pipeline, only see the
+// cells of their regions: the other cells are blanked out for them and
+// left as they are.
+//
+// The decorator must keep the text it is given, adding escape sequences
+// only, which is what all decorators in this package do.
+func DecorateGrid(g cowsay.Grid, d Decorator) cowsay.Grid {
+	switch d := d.(type) {
+	case Pipeline:
+		for _, step := range d {
+			g = DecorateGrid(g, step)
+		}
+		return g
+	case regional:
+		return decorateCells(g, d.Decorator, d.regions)
+	}
+	return decorateCells(g, d, nil)
+}
+
+// decorateCells applies d to the cells of g in regions, or to all cells if
+// regions is nil.
+func decorateCells(g cowsay.Grid, d Decorator, regions []cowsay.Region) cowsay.Grid {
+	in := func(c cowsay.Cell) bool {
+		return regions == nil || slices.Contains(regions, c.Region)
+	}
+
+	var masked strings.Builder
+	for _, row := range g {
+		for _, c := range row {
+			if in(c) {
+				masked.WriteString(c.Text)
+			} else {
+				masked.WriteString(strings.Repeat(" ", c.Width()))
+			}
+		}
+		masked.WriteByte('\n')
+	}
+	out := string(d.Decorate([]byte(masked.String())))
+
+	// take the decorated text apart again, cell by cell
+	decorated := make(cowsay.Grid, len(g))
+	for i, row := range g {
+		cells := make([]cowsay.Cell, 0, len(row))
+		for _, c := range row {
+			var text string
+			if in(c) {
+				text, out = takeRunes(out, visibleRunes(c.Text))
+			} else {
+				// keep what d added around the blanks, but not the blanks
+				var blanks string
+				blanks, out = takeRunes(out, c.Width())
+				text = escapes(blanks) + c.Text
+			}
+			cells = append(cells, cowsay.Cell{Text: text, Region: c.Region})
+		}
+
+		// escape sequences at the end of the line
+		rest, after, _ := strings.Cut(out, "\n")
+		out = after
+		if rest != "" {
+			if n := len(cells); n > 0 && in(row[n-1]) {
+				cells[n-1].Text += rest
+			} else {
+				cells = append(cells, cowsay.Cell{Text: rest, Region: cellRegion(row)})
+			}
+		}
+		decorated[i] = cells
+	}
+	return decorated
+}
+
+// takeRunes splits s after its first n runes that are not part of an
+// escape sequence. It stops early at a newline.
+func takeRunes(s string, n int) (head, tail string) {
+	i := 0
+	for n > 0 && i < len(s) && s[i] != '\n' {
+		if s[i] == ansi.Esc {
+			i += ansi.EscapeLen(s[i:])
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		n--
+	}
+	return s[:i], s[i:]
+}
+
+// visibleRunes returns the number of runes in s outside escape sequences.
+func visibleRunes(s string) int {
+	n := 0
+	for len(s) > 0 {
+		if s[0] == ansi.Esc {
+			s = s[ansi.EscapeLen(s):]
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s)
+		s = s[size:]
+		n++
+	}
+	return n
+}
+
+// escapes returns the escape sequences in s.
+func escapes(s string) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexByte(s, ansi.Esc)
+		if i < 0 {
+			break
+		}
+		n := ansi.EscapeLen(s[i:])
+		b.WriteString(s[i : i+n])
+		s = s[i+n:]
+	}
+	return b.String()
+}
+
+// cellRegion returns the region of the last cell of row, for escape
+// sequences appended to it.
+func cellRegion(row []cowsay.Cell) cowsay.Region {
+	if len(row) == 0 {
+		return cowsay.RegionBody
+	}
+	return row[len(row)-1].Region
+}
@@ -0,0 +1,109 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/cowsay"
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func renderGrid(t *testing.T, msg string) cowsay.Grid {
+	t.Helper()
+	c := cowsay.NewCow("default", "", cowsay.InBinary)
+	c.Tongue = "U"
+	grid, err := c.RenderGrid(msg)
+	if err != nil {
+		t.Fatalf("RenderGrid(%q) failed: %v", msg, err)
+	}
+	return grid
+}
+
+func TestDecorateGrid(t *testing.T) {
+	grid := renderGrid(t, "Hi")
+	plain := string(grid.Bytes())
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{
+			name: "everything",
+			spec: "rainbow",
+			want: string(decoration.Rainbow([]byte(plain))),
+		},
+		{
+			name: "text only",
+			spec: "bold:region=text",
+			want: strings.Replace(plain, "< Hi >", "< \x1b[1mHi\x1b[0m >", 1),
+		},
+		{
+			name: "eyes and tongue",
+			spec: "bold:region=eyes+tongue",
+			want: strings.NewReplacer(
+				"(oo)", "(\x1b[1moo\x1b[0m)",
+				"U  ||", "\x1b[1mU\x1b[0m  ||",
+			).Replace(plain),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := decoration.Parse(tc.spec, decoration.ProfileTrueColor)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.spec, err)
+			}
+			got := string(decoration.DecorateGrid(grid, p).Bytes())
+			if got != tc.want {
+				t.Fatalf("DecorateGrid(%q) = %q, want %q", tc.spec, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecorateGridKeepsPositions(t *testing.T) {
+	grid := renderGrid(t, "Hi")
+	everywhere := decoration.DecorateGrid(grid, decoration.DecoratorFunc(decoration.Rainbow))
+
+	p, err := decoration.Parse("rainbow:region=text,bold:region=border", decoration.ProfileTrueColor)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	got := decoration.DecorateGrid(grid, p)
+
+	// the text gets the colors it has in a full rainbow, and the border
+	// of the same line is bold instead
+	want := "\x1b[1m<\x1b[0m " + everywhere[1][2].Text + everywhere[1][3].Text + "\x1b[0m \x1b[1m>\x1b[0m"
+	var line string
+	for _, c := range got[1] {
+		line += c.Text
+	}
+	if line != want {
+		t.Fatalf("line 1 = %q, want %q", line, want)
+	}
+	if tail := got[3][8]; tail.Region != cowsay.RegionTail || tail.Text != `\` {
+		t.Errorf("tail cell = %+v, want it undecorated", tail)
+	}
+}
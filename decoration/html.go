@@ -0,0 +1,257 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+)
+
+// HTMLTheme sets the default text and background colors of HTML output.
+type HTMLTheme int
+
+const (
+	// HTMLThemeDark is light text on black, like most terminals.
+	HTMLThemeDark HTMLTheme = iota
+	// HTMLThemeLight is black text on white.
+	HTMLThemeLight
+)
+
+var htmlThemes = []struct {
+	name   string
+	fg, bg Color
+}{
+	HTMLThemeDark:  {"dark", ansi16[7], ansi16[0]},
+	HTMLThemeLight: {"light", ansi16[0], ansi16[15]},
+}
+
+// String returns the name of the theme.
+func (t HTMLTheme) String() string {
+	if t < 0 || int(t) >= len(htmlThemes) {
+		return fmt.Sprintf("HTMLTheme(%d)", int(t))
+	}
+	return htmlThemes[t].name
+}
+
+// ParseHTMLTheme returns the HTML theme with the given name.
+func ParseHTMLTheme(name string) (HTMLTheme, error) {
+	for i, t := range htmlThemes {
+		if t.name == name {
+			return HTMLTheme(i), nil
+		}
+	}
+	return HTMLThemeDark, fmt.Errorf("unknown HTML theme %q", name)
+}
+
+// HTMLOptions tunes the output of HTML.
+type HTMLOptions struct {
+	// Theme gives the colors of text that sets none.
+	Theme HTMLTheme
+	// Page wraps the <pre> block in a complete HTML page.
+	Page bool
+	// Title is the title of the page. "" means "cowsay".
+	Title string
+}
+
+// HTML converts text with SGR sequences, such as decorated cowsay output,
+// to a self-contained <pre> block in which spans with inline CSS take the
+// place of the sequences. Other escape sequences and control characters
+// are dropped, blinking is left out and concealed text is transparent.
+func HTML(input []byte, opts HTMLOptions) []byte {
+	var buf bytes.Buffer
+	theme := htmlThemes[HTMLThemeDark]
+	if opts.Theme >= 0 && int(opts.Theme) < len(htmlThemes) {
+		theme = htmlThemes[opts.Theme]
+	}
+
+	if opts.Page {
+		title := opts.Title
+		if title == "" {
+			title = "cowsay"
+		}
+		buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+		writeHTMLText(&buf, title)
+		buf.WriteString("</title>\n</head>\n<body style=\"margin:0;background-color:")
+		buf.WriteString(cssColor(theme.bg))
+		buf.WriteString("\">\n")
+	}
+	buf.WriteString("<pre style=\"color:")
+	buf.WriteString(cssColor(theme.fg))
+	buf.WriteString(";background-color:")
+	buf.WriteString(cssColor(theme.bg))
+	buf.WriteString(";padding:0.5em\">")
+
+	// cur is the pen set by the input so far and open the one of the
+	// span being written, if any
+	var cur, open pen
+	inSpan := false
+	text := string(input)
+	for len(text) > 0 {
+		if text[0] == ansi.Esc {
+			n := ansi.EscapeLen(text)
+			if seq := text[:n]; ansi.IsSGR(seq) {
+				r := sgrReader{s: seq[2 : len(seq)-1], sep: ";"}
+				for param, ok := r.next(); ok; param, ok = r.next() {
+					cur.apply(param)
+				}
+			}
+			text = text[n:]
+			continue
+		}
+
+		n := strings.IndexByte(text, ansi.Esc)
+		if n < 0 {
+			n = len(text)
+		}
+		if inSpan && open != cur {
+			buf.WriteString("</span>")
+			inSpan = false
+		}
+		if !inSpan && cur != (pen{}) {
+			// a pen may have nothing to show, as with blinking alone
+			if style := htmlStyle(cur, theme.fg, theme.bg); style != "" {
+				buf.WriteString("<span style=\"")
+				buf.WriteString(style)
+				buf.WriteString("\">")
+				open, inSpan = cur, true
+			}
+		}
+		writeHTMLText(&buf, text[:n])
+		text = text[n:]
+	}
+	if inSpan {
+		buf.WriteString("</span>")
+	}
+
+	buf.WriteString("</pre>\n")
+	if opts.Page {
+		buf.WriteString("</body>\n</html>\n")
+	}
+	return buf.Bytes()
+}
+
+// htmlStyle returns the inline CSS that writes text with pen p, where fg
+// and bg are the default colors.
+func htmlStyle(p pen, fg, bg Color) string {
+	var decls []string
+	fgSet, bgSet := p.fg.kind != colorUnset, p.bg.kind != colorUnset
+	if fgSet {
+		fg = p.fg.toRGB()
+	}
+	if bgSet {
+		bg = p.bg.toRGB()
+	}
+	if p.attrs&AttrInverse != 0 {
+		fg, bg = bg, fg
+		fgSet, bgSet = true, true
+	}
+	if p.attrs&AttrDim != 0 {
+		// halfway to the background
+		fg = Color{uint8((int(fg.R) + int(bg.R)) / 2), uint8((int(fg.G) + int(bg.G)) / 2), uint8((int(fg.B) + int(bg.B)) / 2)}
+		fgSet = true
+	}
+
+	switch {
+	case p.attrs&AttrConceal != 0:
+		decls = append(decls, "color:transparent")
+	case fgSet:
+		decls = append(decls, "color:"+cssColor(fg))
+	}
+	if bgSet {
+		decls = append(decls, "background-color:"+cssColor(bg))
+	}
+	if p.attrs&AttrBold != 0 {
+		decls = append(decls, "font-weight:bold")
+	}
+	if p.attrs&AttrItalic != 0 {
+		decls = append(decls, "font-style:italic")
+	}
+	var lines []string
+	if p.attrs&AttrUnderline != 0 {
+		lines = append(lines, "underline")
+	}
+	if p.attrs&AttrStrike != 0 {
+		lines = append(lines, "line-through")
+	}
+	if lines != nil {
+		decls = append(decls, "text-decoration:"+strings.Join(lines, " "))
+	}
+	return strings.Join(decls, ";")
+}
+
+// toRGB returns the RGB value of the color, taking the ANSI colors and
+// the 256-color palette to be the xterm defaults.
+func (c StyleColor) toRGB() Color {
+	i := int(c.index)
+	switch {
+	case c.kind == colorRGB:
+		return c.rgb
+	case i < 16:
+		return ansi16[i]
+	case i < 232:
+		i -= 16
+		return Color{cubeLevels[i/36], cubeLevels[i/6%6], cubeLevels[i%6]}
+	}
+	level := uint8(8 + 10*(i-232))
+	return Color{level, level, level}
+}
+
+// cssColor returns c as a CSS hex color.
+func cssColor(c Color) string {
+	const digits = "0123456789abcdef"
+	return string([]byte{'#',
+		digits[c.R>>4], digits[c.R&15],
+		digits[c.G>>4], digits[c.G&15],
+		digits[c.B>>4], digits[c.B&15],
+	})
+}
+
+// writeHTMLText writes s escaped for HTML, leaving out control characters
+// other than line breaks and tabs and replacing invalid UTF-8 with U+FFFD.
+func writeHTMLText(buf *bytes.Buffer, s string) {
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		switch {
+		case r == '<':
+			buf.WriteString("&lt;")
+		case r == '>':
+			buf.WriteString("&gt;")
+		case r == '&':
+			buf.WriteString("&amp;")
+		case r == '"':
+			buf.WriteString("&#34;")
+		case r == '\'':
+			buf.WriteString("&#39;")
+		case r == utf8.RuneError && size == 1:
+			buf.WriteRune(utf8.RuneError)
+		case r == '\n' || r == '\t' || !unicode.IsControl(r):
+			buf.WriteString(s[:size])
+		}
+		s = s[size:]
+	}
+}
@@ -0,0 +1,117 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func TestHTML(t *testing.T) {
+	const pre = `<pre style="color:#e5e5e5;background-color:#000000;padding:0.5em">`
+	tests := []struct {
+		name    string
+		msg     string
+		wantMsg string
+	}{
+		{
+			name:    "escaped text",
+			msg:     "< a & b >\n  \\   ^__^\n\"'",
+			wantMsg: pre + "&lt; a &amp; b &gt;\n  \\   ^__^\n&#34;&#39;</pre>\n",
+		},
+		{
+			name:    "one span per run",
+			msg:     "\x1b[38;2;255;0;0mab\x1b[38;2;0;0;255mc\x1b[0m d",
+			wantMsg: pre + `<span style="color:#ff0000">ab</span><span style="color:#0000ff">c</span> d</pre>` + "\n",
+		},
+		{
+			name:    "attributes",
+			msg:     "\x1b[1;3;4;9mx\x1b[22;23;24;29my",
+			wantMsg: pre + `<span style="font-weight:bold;font-style:italic;text-decoration:underline line-through">x</span>y</pre>` + "\n",
+		},
+		{
+			name:    "palette colors",
+			msg:     "\x1b[31;104ma\x1b[38;5;196;48;5;244mb\x1b[38:2::0:128:0mc",
+			wantMsg: pre + `<span style="color:#cd0000;background-color:#5c5cff">a</span><span style="color:#ff0000;background-color:#808080">b</span><span style="color:#008000;background-color:#808080">c</span></pre>` + "\n",
+		},
+		{
+			name:    "inverse and dim use the theme",
+			msg:     "\x1b[7ma\x1b[0m\x1b[2;34mb",
+			wantMsg: pre + `<span style="color:#000000;background-color:#e5e5e5">a</span><span style="color:#000077">b</span></pre>` + "\n",
+		},
+		{
+			name:    "concealed and blinking",
+			msg:     "\x1b[8;41ma\x1b[28mb\x1b[0m\x1b[5mc",
+			wantMsg: pre + `<span style="color:transparent;background-color:#cd0000">a</span><span style="background-color:#cd0000">b</span>c</pre>` + "\n",
+		},
+		{
+			name:    "other escapes and controls dropped",
+			msg:     "a\x1b]0;title\a\x1b[2Jb\x07c\xff",
+			wantMsg: pre + "abc�</pre>\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := decoration.HTML([]byte(tc.msg), decoration.HTMLOptions{}); string(got) != tc.wantMsg {
+				t.Fatalf("HTML(%q) = %q, want %q", tc.msg, got, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHTMLPage(t *testing.T) {
+	got := string(decoration.HTML([]byte("moo"), decoration.HTMLOptions{
+		Theme: decoration.HTMLThemeLight,
+		Page:  true,
+		Title: "Cows & bulls",
+	}))
+	for _, want := range []string{
+		"<!DOCTYPE html>\n",
+		"<title>Cows &amp; bulls</title>",
+		`<body style="margin:0;background-color:#ffffff">`,
+		`<pre style="color:#000000;background-color:#ffffff;padding:0.5em">moo</pre>`,
+		"</html>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML page = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestParseHTMLTheme(t *testing.T) {
+	for _, name := range []string{"dark", "light"} {
+		theme, err := decoration.ParseHTMLTheme(name)
+		if err != nil {
+			t.Fatalf("ParseHTMLTheme(%q) failed: %v", name, err)
+		}
+		if theme.String() != name {
+			t.Fatalf("ParseHTMLTheme(%q) = %v", name, theme)
+		}
+	}
+	if _, err := decoration.ParseHTMLTheme("sepia"); err == nil {
+		t.Fatal(`ParseHTMLTheme("sepia") succeeded, want an error`)
+	}
+}
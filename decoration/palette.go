@@ -0,0 +1,253 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Palette is a named list of colors.
+type Palette struct {
+	Name   string
+	Colors []Color
+}
+
+// palettes are the built-in palettes, flags first.
+var palettes = []Palette{
+	newPalette("pride", "#e40303", "#ff8c00", "#ffed00", "#008026", "#004dff", "#750787"),
+	newPalette("trans", "#5bcefa", "#f5a9b8", "#ffffff", "#f5a9b8", "#5bcefa"),
+	newPalette("bi", "#d60270", "#d60270", "#9b4f96", "#0038a8", "#0038a8"),
+	newPalette("lesbian", "#d52d00", "#ef7627", "#ff9a56", "#ffffff", "#d162a4", "#b55690", "#a30262"),
+	newPalette("nonbinary", "#fcf434", "#ffffff", "#9c59d1", "#2c2c2c"),
+	// the accent colors of each theme
+	newPalette("solarized", "#b58900", "#cb4b16", "#dc322f", "#d33682", "#6c71c4", "#268bd2", "#2aa198", "#859900"),
+	newPalette("nord", "#8fbcbb", "#88c0d0", "#81a1c1", "#5e81ac", "#bf616a", "#d08770", "#ebcb8b", "#a3be8c", "#b48ead"),
+	newPalette("dracula", "#8be9fd", "#50fa7b", "#ffb86c", "#ff79c6", "#bd93f9", "#ff5555", "#f1fa8c"),
+}
+
+// newPalette builds a built-in palette from hex colors.
+func newPalette(name string, hex ...string) Palette {
+	p := Palette{Name: name}
+	for _, h := range hex {
+		c, err := ParseHexColor(h)
+		if err != nil {
+			panic(err)
+		}
+		p.Colors = append(p.Colors, c)
+	}
+	return p
+}
+
+// PaletteNames returns the names of the built-in palettes.
+func PaletteNames() []string {
+	names := make([]string, len(palettes))
+	for i, p := range palettes {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// ParsePalette returns the built-in palette with the given name.
+func ParsePalette(name string) (Palette, error) {
+	for _, p := range palettes {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Palette{}, fmt.Errorf("unknown palette %q", name)
+}
+
+// LoadPalette reads a palette from the file at path. A palette without a
+// name is named after the file.
+func LoadPalette(path string) (Palette, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Palette{}, fmt.Errorf("failed to open palette: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	p, err := ReadPalette(f)
+	if err != nil {
+		return Palette{}, fmt.Errorf("palette %q: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return p, nil
+}
+
+// ReadPalette reads a palette from r. The input has one "key = value" pair
+// per line, as balloon style files do; blank lines and lines starting with
+// '#' are ignored:
+//
+//	# company colors
+//	name = acme
+//	colors = #d52d00 #ef7627
+//	colors = #ffffff
+//
+// Every "colors" line adds hex colors, separated by spaces or commas.
+func ReadPalette(r io.Reader) (Palette, error) {
+	var p Palette
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return Palette{}, fmt.Errorf("line %d: missing '='", line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "name":
+			p.Name = value
+		case "colors":
+			for _, hex := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				c, err := ParseHexColor(hex)
+				if err != nil {
+					return Palette{}, fmt.Errorf("line %d: %w", line, err)
+				}
+				p.Colors = append(p.Colors, c)
+			}
+		default:
+			return Palette{}, fmt.Errorf("line %d: unknown key %q", line, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Palette{}, err
+	}
+	if len(p.Colors) == 0 {
+		return Palette{}, errors.New("no colors")
+	}
+	return p, nil
+}
+
+// PaletteMode is how a palette colors the output.
+type PaletteMode int
+
+const (
+	// PaletteBands gives every line the next color, in horizontal bands.
+	PaletteBands PaletteMode = iota
+	// PaletteChars gives every visible character the next color.
+	PaletteChars
+)
+
+var paletteModeNames = []string{
+	PaletteBands: "bands",
+	PaletteChars: "chars",
+}
+
+// String returns the name of the palette mode.
+func (m PaletteMode) String() string {
+	if m < 0 || int(m) >= len(paletteModeNames) {
+		return fmt.Sprintf("PaletteMode(%d)", int(m))
+	}
+	return paletteModeNames[m]
+}
+
+// ParsePaletteMode returns the palette mode with the given name.
+func ParsePaletteMode(name string) (PaletteMode, error) {
+	for i, n := range paletteModeNames {
+		if n == name {
+			return PaletteMode(i), nil
+		}
+	}
+	return PaletteBands, fmt.Errorf("unknown palette mode %q", name)
+}
+
+// NewPaletteDecorator returns a decorator that colors text by cycling
+// through the colors of p, limited to the color profile.
+func NewPaletteDecorator(p Palette, mode PaletteMode, profile Profile) (Decorator, error) {
+	if len(p.Colors) == 0 {
+		return nil, fmt.Errorf("palette %q has no colors", p.Name)
+	}
+	d := paletteDecorator{mode: mode}
+	for _, c := range p.Colors {
+		d.pens = append(d.pens, pen{fg: profile.quantize(c)})
+	}
+	return d, nil
+}
+
+// paletteDecorator is the "palette" decorator. Its parameters are name, a
+// built-in palette (pride by default), file, a palette file to load
+// instead, and mode.
+type paletteDecorator struct {
+	// pens write the palette colors
+	pens []pen
+	mode PaletteMode
+}
+
+func newPaletteDecorator(params Params, profile Profile) (Decorator, error) {
+	if _, ok := params["file"]; ok && params["name"] != "" {
+		return nil, errors.New("name and file cannot be combined")
+	}
+
+	p := palettes[0]
+	mode := PaletteBands
+	for key, value := range params {
+		var err error
+		switch key {
+		case "name":
+			p, err = ParsePalette(value)
+		case "file":
+			p, err = LoadPalette(value)
+		case "mode":
+			mode, err = ParsePaletteMode(value)
+		default:
+			err = fmt.Errorf("unknown parameter %q", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewPaletteDecorator(p, mode, profile)
+}
+
+// Decorate implements Decorator.
+func (d paletteDecorator) Decorate(input []byte) []byte {
+	// next is the index of the next color: per line for bands and per
+	// visible character otherwise
+	next := 0
+	return decorate(input, func(rn rune, _ string) pen {
+		if rn == '\n' && d.mode == PaletteBands {
+			next++
+		}
+		if unicode.IsSpace(rn) {
+			return pen{}
+		}
+
+		p := d.pens[next%len(d.pens)]
+		if d.mode == PaletteChars {
+			next++
+		}
+		return p
+	})
+}
@@ -0,0 +1,201 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func TestPaletteDecorator(t *testing.T) {
+	redBlue := decoration.Palette{Name: "test", Colors: []decoration.Color{{R: 255}, {B: 255}}}
+	tests := []struct {
+		name    string
+		mode    decoration.PaletteMode
+		profile decoration.Profile
+		msg     string
+		wantMsg string
+	}{
+		{
+			name:    "bands",
+			mode:    decoration.PaletteBands,
+			profile: decoration.ProfileTrueColor,
+			msg:     "ab\nc\nd\n",
+			wantMsg: "\x1b[38;2;255;0;0mab\x1b[0m\n\x1b[38;2;0;0;255mc\x1b[0m\n\x1b[38;2;255;0;0md\x1b[0m\n",
+		},
+		{
+			name:    "chars skip spaces and run across lines",
+			mode:    decoration.PaletteChars,
+			profile: decoration.ProfileTrueColor,
+			msg:     "a b\nc",
+			wantMsg: "\x1b[38;2;255;0;0ma\x1b[0m \x1b[38;2;0;0;255mb\x1b[0m\n\x1b[38;2;255;0;0mc\x1b[0m",
+		},
+		{
+			name:    "color depth",
+			mode:    decoration.PaletteChars,
+			profile: decoration.Profile16,
+			msg:     "ab",
+			wantMsg: "\x1b[91ma\x1b[34mb\x1b[0m",
+		},
+		{
+			name:    "no colors",
+			mode:    decoration.PaletteBands,
+			profile: decoration.ProfileNone,
+			msg:     "ab\n",
+			wantMsg: "ab\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := decoration.NewPaletteDecorator(redBlue, tc.mode, tc.profile)
+			if err != nil {
+				t.Fatalf("NewPaletteDecorator failed: %v", err)
+			}
+			if got := d.Decorate([]byte(tc.msg)); tc.wantMsg != string(got) {
+				t.Fatalf("palette %v on %q = %q, want %q", tc.mode, tc.msg, got, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParsePalette(t *testing.T) {
+	for _, name := range decoration.PaletteNames() {
+		p, err := decoration.ParsePalette(name)
+		if err != nil {
+			t.Fatalf("ParsePalette(%q) failed: %v", name, err)
+		}
+		if p.Name != name || len(p.Colors) == 0 {
+			t.Fatalf("ParsePalette(%q) = %+v", name, p)
+		}
+	}
+	if _, err := decoration.ParsePalette("beige"); err == nil {
+		t.Fatalf("ParsePalette(%q) succeeded, want error", "beige")
+	}
+}
+
+func TestReadPalette(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantName   string
+		wantColors int
+		wantErr    string
+	}{
+		{
+			name:       "colors over several lines",
+			input:      "# company colors\nname = acme\ncolors = #d52d00 #ef7627,\ncolors = fff\n",
+			wantName:   "acme",
+			wantColors: 3,
+		},
+		{
+			name:    "bad color",
+			input:   "colors = #d52d00 orange\n",
+			wantErr: "line 1: invalid hex color",
+		},
+		{
+			name:    "unknown key",
+			input:   "\ncolour = #d52d00\n",
+			wantErr: `line 2: unknown key "colour"`,
+		},
+		{
+			name:    "missing equals",
+			input:   "#d52d00\ncolors #d52d00\n",
+			wantErr: "line 2: missing '='",
+		},
+		{
+			name:    "no colors",
+			input:   "name = empty\n",
+			wantErr: "no colors",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := decoration.ReadPalette(strings.NewReader(tc.input))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ReadPalette() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadPalette() failed: %v", err)
+			}
+			if p.Name != tc.wantName || len(p.Colors) != tc.wantColors {
+				t.Fatalf("ReadPalette() = %+v, want name %q and %d colors", p, tc.wantName, tc.wantColors)
+			}
+		})
+	}
+}
+
+func TestLoadPalette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brand.palette")
+	if err := os.WriteFile(path, []byte("colors = #ff0000 #0000ff\n"), 0o644); err != nil {
+		t.Fatalf("failed to write palette: %v", err)
+	}
+
+	p, err := decoration.LoadPalette(path)
+	if err != nil {
+		t.Fatalf("LoadPalette failed: %v", err)
+	}
+	if p.Name != "brand" {
+		t.Fatalf("LoadPalette(%q).Name = %q, want %q", path, p.Name, "brand")
+	}
+
+	d, err := decoration.Parse("palette:mode=chars:file="+path, decoration.ProfileTrueColor)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := "\x1b[38;2;255;0;0ma\x1b[38;2;0;0;255mb\x1b[0m"
+	if got := d.Decorate([]byte("ab")); string(got) != want {
+		t.Fatalf("palette from %q on %q = %q, want %q", path, "ab", got, want)
+	}
+}
+
+func TestPaletteParams(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"palette", false},
+		{"palette:name=nord:mode=chars", false},
+		{"palette:name=beige", true},
+		{"palette:mode=stripes", true},
+		{"palette:file=/nonexistent/palette", true},
+		{"palette:name=nord:file=x", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			_, err := decoration.Parse(tc.spec, decoration.ProfileTrueColor)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tc.spec, err, tc.wantErr)
+			}
+		})
+	}
+}
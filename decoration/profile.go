@@ -0,0 +1,160 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Profile is the range of colors a terminal can show.
+type Profile int
+
+const (
+	// ProfileNone means no colors or other attributes at all.
+	ProfileNone Profile = iota
+	// Profile16 is the 8 standard and 8 bright ANSI colors.
+	Profile16
+	// Profile256 is the xterm 256-color palette.
+	Profile256
+	// ProfileTrueColor is 24-bit RGB.
+	ProfileTrueColor
+)
+
+var profileNames = []string{
+	ProfileNone:      "none",
+	Profile16:        "16",
+	Profile256:       "256",
+	ProfileTrueColor: "truecolor",
+}
+
+// String returns the name of the profile.
+func (p Profile) String() string {
+	if p < 0 || int(p) >= len(profileNames) {
+		return fmt.Sprintf("Profile(%d)", int(p))
+	}
+	return profileNames[p]
+}
+
+// ParseProfile returns the profile with the given name.
+func ParseProfile(name string) (Profile, error) {
+	for i, n := range profileNames {
+		if n == name {
+			return Profile(i), nil
+		}
+	}
+	return ProfileNone, fmt.Errorf("unknown color profile %q", name)
+}
+
+// trueColorTerms are terminals known to support 24-bit color whose TERM
+// does not say so. COLORTERM usually does, but it is not passed on by ssh.
+var trueColorTerms = map[string]bool{
+	"alacritty":     true,
+	"foot":          true,
+	"wezterm":       true,
+	"xterm-ghostty": true,
+	"xterm-kitty":   true,
+}
+
+// DetectProfile returns the color profile described by the environment:
+// none if NO_COLOR is set, otherwise what COLORTERM and TERM advertise.
+func DetectProfile(getenv func(string) string) Profile {
+	if getenv("NO_COLOR") != "" {
+		return ProfileNone
+	}
+	return termProfile(getenv)
+}
+
+func termProfile(getenv func(string) string) Profile {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+
+	term := getenv("TERM")
+	switch {
+	case term == "" || term == "dumb":
+		return ProfileNone
+	case strings.HasSuffix(term, "-direct") || trueColorTerms[term]:
+		return ProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return Profile256
+	}
+	// xterm, screen, linux, vt100 and the like
+	return Profile16
+}
+
+// ColorMode says when to use colors, as chosen by --color.
+type ColorMode int
+
+const (
+	// ColorAuto uses colors only on a terminal, as far as the environment
+	// allows.
+	ColorAuto ColorMode = iota
+	// ColorAlways uses colors even when not writing to a terminal or when
+	// NO_COLOR is set.
+	ColorAlways
+	// ColorNever never uses colors.
+	ColorNever
+)
+
+var colorModeNames = []string{
+	ColorAuto:   "auto",
+	ColorAlways: "always",
+	ColorNever:  "never",
+}
+
+// String returns the name of the color mode.
+func (m ColorMode) String() string {
+	if m < 0 || int(m) >= len(colorModeNames) {
+		return fmt.Sprintf("ColorMode(%d)", int(m))
+	}
+	return colorModeNames[m]
+}
+
+// ParseColorMode returns the color mode with the given name.
+func ParseColorMode(name string) (ColorMode, error) {
+	for i, n := range colorModeNames {
+		if n == name {
+			return ColorMode(i), nil
+		}
+	}
+	return ColorAuto, fmt.Errorf("unknown color mode %q", name)
+}
+
+// Profile returns the color profile to use for output that does or does
+// not go to a terminal. ColorAlways falls back to 16 colors when the
+// environment names no color terminal, since those are understood almost
+// everywhere.
+func (m ColorMode) Profile(getenv func(string) string, terminal bool) Profile {
+	switch m {
+	case ColorNever:
+		return ProfileNone
+	case ColorAlways:
+		return max(termProfile(getenv), Profile16)
+	}
+	if !terminal {
+		return ProfileNone
+	}
+	return DetectProfile(getenv)
+}
@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+// env returns a getenv function over the given variables.
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want decoration.Profile
+	}{
+		{"nothing set", nil, decoration.ProfileNone},
+		{"dumb terminal", map[string]string{"TERM": "dumb"}, decoration.ProfileNone},
+		{"linux console", map[string]string{"TERM": "linux"}, decoration.Profile16},
+		{"old tmux", map[string]string{"TERM": "screen"}, decoration.Profile16},
+		{"xterm-256color", map[string]string{"TERM": "xterm-256color"}, decoration.Profile256},
+		{"tmux-256color", map[string]string{"TERM": "tmux-256color"}, decoration.Profile256},
+		{"direct color", map[string]string{"TERM": "xterm-direct"}, decoration.ProfileTrueColor},
+		{"kitty over ssh", map[string]string{"TERM": "xterm-kitty"}, decoration.ProfileTrueColor},
+		{"colorterm truecolor", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, decoration.ProfileTrueColor},
+		{"colorterm 24bit", map[string]string{"TERM": "xterm", "COLORTERM": "24bit"}, decoration.ProfileTrueColor},
+		{"colorterm other", map[string]string{"TERM": "xterm", "COLORTERM": "yes"}, decoration.Profile16},
+		{"no color", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor", "NO_COLOR": "1"}, decoration.ProfileNone},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := decoration.DetectProfile(env(tc.env)); got != tc.want {
+				t.Fatalf("DetectProfile(%v) = %v, want %v", tc.env, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestColorModeProfile(t *testing.T) {
+	color := map[string]string{"TERM": "xterm-256color"}
+	noColor := map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1"}
+	tests := []struct {
+		name     string
+		mode     decoration.ColorMode
+		env      map[string]string
+		terminal bool
+		want     decoration.Profile
+	}{
+		{"auto on terminal", decoration.ColorAuto, color, true, decoration.Profile256},
+		{"auto to pipe", decoration.ColorAuto, color, false, decoration.ProfileNone},
+		{"auto with NO_COLOR", decoration.ColorAuto, noColor, true, decoration.ProfileNone},
+		{"always to pipe", decoration.ColorAlways, color, false, decoration.Profile256},
+		{"always with NO_COLOR", decoration.ColorAlways, noColor, false, decoration.Profile256},
+		{"always without TERM", decoration.ColorAlways, nil, false, decoration.Profile16},
+		{"never", decoration.ColorNever, color, true, decoration.ProfileNone},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.mode.Profile(env(tc.env), tc.terminal); got != tc.want {
+				t.Fatalf("%v.Profile(%v, %v) = %v, want %v", tc.mode, tc.env, tc.terminal, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseColorMode(t *testing.T) {
+	for _, name := range []string{"auto", "always", "never"} {
+		m, err := decoration.ParseColorMode(name)
+		if err != nil {
+			t.Fatalf("ParseColorMode(%q) failed: %v", name, err)
+		}
+		if m.String() != name {
+			t.Fatalf("ParseColorMode(%q).String() = %q", name, m.String())
+		}
+	}
+	if _, err := decoration.ParseColorMode("sometimes"); err == nil {
+		t.Fatalf("ParseColorMode(%q) succeeded, want error", "sometimes")
+	}
+}
@@ -23,59 +23,158 @@
 package decoration
 
 import (
-	"bytes"
+	"cmp"
 	"fmt"
+	"hash/fnv"
 	"math"
+	"math/rand/v2"
+	"strconv"
 	"unicode"
-	"unicode/utf8"
 )
 
 const (
-	freq       = 0.35
-	redPhase   = 0
-	greenPhase = math.Pi * 2 / 3
-	bluePhase  = math.Pi * 4 / 3
-	step       = 0.9
-	offset     = 3.5
+	defaultFrequency = 0.35
+	redPhase         = 0
+	greenPhase       = math.Pi * 2 / 3
+	bluePhase        = math.Pi * 4 / 3
+	step             = 0.9
+	offset           = 3.5
 )
 
-// rgb returns 1...255 r,g,b for position p.
-func rgb(i float64) (red, green, blue int64) {
-	red = int64(math.Sin(freq*i+redPhase)*127 + 128)
-	green = int64(math.Sin(freq*i+greenPhase)*127 + 128)
-	blue = int64(math.Sin(freq*i+bluePhase)*127 + 128)
-	return
+// RainbowOptions tunes the rainbow. The zero value gives the classic
+// cowsay rainbow.
+type RainbowOptions struct {
+	// Frequency is how quickly the colors cycle. 0 means 0.35.
+	Frequency float64
+	// Spread stretches each color over more columns, as lolcat's --spread
+	// does; 2 makes the rainbow twice as wide. 0 means 1.
+	Spread float64
+	// Seed shifts where on the rainbow the colors start; see HashSeed for
+	// a seed that depends on the message.
+	Seed float64
+	// Direction is the direction in which the colors change. Radial is not
+	// supported and treated as diagonal.
+	Direction Direction
 }
 
-// Rainbow applies rainbow colors to the input text.
+// rgb returns the 1...255 color for position i on a wave of frequency freq.
+func rgb(freq, i float64) Color {
+	return Color{
+		R: uint8(math.Sin(freq*i+redPhase)*127 + 128),
+		G: uint8(math.Sin(freq*i+greenPhase)*127 + 128),
+		B: uint8(math.Sin(freq*i+bluePhase)*127 + 128),
+	}
+}
+
+// Rainbow applies rainbow colors to the input text, in 24-bit color.
 func Rainbow(input []byte) []byte {
-	var buf bytes.Buffer
-	lineIndex := 0
-	pos := float64(lineIndex)*offset + 1
+	return NewRainbow(RainbowOptions{}, ProfileTrueColor).Decorate(input)
+}
 
-	for len(input) > 0 {
-		rn, size := utf8.DecodeRune(input)
-		if rn == '\n' {
-			lineIndex++
-			pos = float64(lineIndex)*offset + 1
-			buf.WriteRune(rn)
-			input = input[size:]
-			continue
-		}
-		if unicode.IsSpace(rn) {
-			buf.WriteRune(rn)
-			input = input[size:]
-			pos += step
-			continue
+// NewRainbow returns a decorator that applies rainbow colors tuned by opts,
+// limited to the color profile.
+func NewRainbow(opts RainbowOptions, profile Profile) Decorator {
+	if opts.Frequency == 0 {
+		opts.Frequency = defaultFrequency
+	}
+	if opts.Spread == 0 {
+		opts.Spread = 1
+	}
+	return rainbow{opts: opts, profile: profile}
+}
+
+// rainbow is the "rainbow" decorator. Its parameters are freq, spread,
+// seed (a number or random) and direction, after RainbowOptions.
+type rainbow struct {
+	opts    RainbowOptions
+	profile Profile
+}
+
+func newRainbow(params Params, profile Profile) (Decorator, error) {
+	var opts RainbowOptions
+	randomSeed := false
+	for key, value := range params {
+		switch key {
+		case "freq", "spread":
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil || f <= 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+				return nil, fmt.Errorf("invalid %s %q", key, value)
+			}
+			if key == "freq" {
+				opts.Frequency = f
+			} else {
+				opts.Spread = f
+			}
+		case "seed":
+			if value == "random" {
+				randomSeed = true
+				break
+			}
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+				return nil, fmt.Errorf("invalid seed %q", value)
+			}
+			opts.Seed = f
+		case "direction":
+			d, err := ParseDirection(value)
+			if err != nil {
+				return nil, err
+			}
+			if d == DirectionRadial {
+				return nil, fmt.Errorf("direction %s is not supported", d)
+			}
+			opts.Direction = d
+		default:
+			return nil, fmt.Errorf("unknown parameter %q", key)
 		}
+	}
+	if randomSeed {
+		opts.Seed = rand.Float64() * period(cmp.Or(opts.Frequency, defaultFrequency))
+	}
+	return NewRainbow(opts, profile), nil
+}
 
-		r, g, b := rgb(pos)
+// period returns the distance after which a wave of frequency freq
+// repeats.
+func period(freq float64) float64 {
+	return 2 * math.Pi / freq
+}
 
-		fmt.Fprintf(&buf, "\x1b[38;2;%d;%d;%dm%c\x1b[0m", r, g, b, rn)
+// HashSeed returns a seed for a rainbow of frequency freq, 0 meaning 0.35,
+// derived from a hash of msg. The same message always gets the same
+// colors and another message most likely different ones, however the cow
+// around it is drawn.
+func HashSeed(msg string, freq float64) float64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(msg))
+	return float64(h.Sum64()>>11) / (1 << 53) * period(cmp.Or(freq, defaultFrequency))
+}
 
-		pos += step
-		input = input[size:]
+// Decorate implements Decorator.
+func (r rainbow) Decorate(input []byte) []byte {
+	seed := r.opts.Seed + 1
+	var lineStep, columnStep float64
+	if r.opts.Direction != DirectionHorizontal {
+		lineStep = offset
+	}
+	if r.opts.Direction != DirectionVertical {
+		columnStep = step / r.opts.Spread
 	}
 
-	return buf.Bytes()
+	lineIndex := 0
+	pos := seed
+
+	return decorate(input, func(rn rune, _ string) pen {
+		if rn == '\n' {
+			lineIndex++
+			pos = seed + float64(lineIndex)*lineStep
+			return pen{}
+		}
+		var p pen
+		if !unicode.IsSpace(rn) {
+			p = pen{fg: r.profile.quantize(rgb(r.opts.Frequency, pos))}
+		}
+		pos += columnStep
+		return p
+	})
 }
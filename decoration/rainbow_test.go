@@ -23,6 +23,8 @@
 package decoration_test
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/xogas/cowsay-go/decoration"
@@ -39,6 +41,11 @@ func TestRainbow(t *testing.T) {
 			msg:     "x y\n",
 			wantMsg: "\x1b[38;2;171;209;2mx\x1b[0m \x1b[38;2;233;136;13my\x1b[0m\n",
 		},
+		{
+			name:    "escape sequences kept",
+			msg:     "\x1b[1mx\x1b[0m\n",
+			wantMsg: "\x1b[1m\x1b[38;2;171;209;2mx\x1b[0m\n",
+		},
 		{
 			name:    "empty input",
 			msg:     "",
@@ -55,3 +62,125 @@ func TestRainbow(t *testing.T) {
 		})
 	}
 }
+
+func TestRainbowProfile(t *testing.T) {
+	msg := "x y\n"
+	tests := []struct {
+		profile decoration.Profile
+		wantMsg string
+	}{
+		{decoration.ProfileTrueColor, "\x1b[38;2;171;209;2mx\x1b[0m \x1b[38;2;233;136;13my\x1b[0m\n"},
+		{decoration.Profile256, "\x1b[38;5;148mx\x1b[0m \x1b[38;5;172my\x1b[0m\n"},
+		{decoration.Profile16, "\x1b[33mx\x1b[0m \x1b[33my\x1b[0m\n"},
+		{decoration.ProfileNone, "x y\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.profile.String(), func(t *testing.T) {
+			p, err := decoration.Parse("rainbow", tc.profile)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if got := p.Decorate([]byte(msg)); tc.wantMsg != string(got) {
+				t.Fatalf("rainbow with profile %v on %q = %q, want %q", tc.profile, msg, got, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestNewRainbow(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    decoration.RainbowOptions
+		msg     string
+		wantMsg string
+	}{
+		{
+			name:    "zero options",
+			opts:    decoration.RainbowOptions{},
+			msg:     "x y\n",
+			wantMsg: "\x1b[38;2;171;209;2mx\x1b[0m \x1b[38;2;233;136;13my\x1b[0m\n",
+		},
+		{
+			name:    "horizontal repeats every line",
+			opts:    decoration.RainbowOptions{Direction: decoration.DirectionHorizontal},
+			msg:     "x y\nx y",
+			wantMsg: "\x1b[38;2;171;209;2mx\x1b[0m \x1b[38;2;233;136;13my\x1b[0m\n\x1b[38;2;171;209;2mx\x1b[0m \x1b[38;2;233;136;13my\x1b[0m",
+		},
+		{
+			name:    "vertical colors whole lines",
+			opts:    decoration.RainbowOptions{Direction: decoration.DirectionVertical},
+			msg:     "x y\nx y",
+			wantMsg: "\x1b[38;2;171;209;2mx\x1b[0m \x1b[38;2;171;209;2my\x1b[0m\n\x1b[38;2;254;64;64mx\x1b[0m \x1b[38;2;254;64;64my\x1b[0m",
+		},
+		{
+			name:    "spread halves the step",
+			opts:    decoration.RainbowOptions{Spread: 2},
+			msg:     "x   y",
+			wantMsg: "\x1b[38;2;171;209;2mx\x1b[0m   \x1b[38;2;233;136;13my\x1b[0m",
+		},
+		{
+			name:    "seed shifts the start",
+			opts:    decoration.RainbowOptions{Seed: 3.5},
+			msg:     "x",
+			wantMsg: "\x1b[38;2;254;64;64mx\x1b[0m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decoration.NewRainbow(tc.opts, decoration.ProfileTrueColor).Decorate([]byte(tc.msg))
+			if tc.wantMsg != string(got) {
+				t.Fatalf("NewRainbow(%+v).Decorate(%q) = %q, want %q", tc.opts, tc.msg, got, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHashSeed(t *testing.T) {
+	seed := decoration.HashSeed("moo", 0)
+	if again := decoration.HashSeed("moo", 0.35); again != seed {
+		t.Fatalf("HashSeed(%q) = %v and then %v", "moo", seed, again)
+	}
+	if seed < 0 || seed >= 2*math.Pi/0.35 {
+		t.Fatalf("HashSeed(%q) = %v, want it within one period", "moo", seed)
+	}
+
+	rb := decoration.NewRainbow(decoration.RainbowOptions{Seed: seed}, decoration.ProfileTrueColor)
+	moo := string(rb.Decorate([]byte("moo")))
+	baa := decoration.NewRainbow(decoration.RainbowOptions{Seed: decoration.HashSeed("baa", 0)}, decoration.ProfileTrueColor)
+	if other := string(baa.Decorate([]byte("moo"))); firstColor(other) == firstColor(moo) {
+		t.Fatalf("different messages got the same colors: %q and %q", moo, other)
+	}
+}
+
+// firstColor returns the first SGR sequence in s, up to its final "m".
+func firstColor(s string) string {
+	seq, _, _ := strings.Cut(s, "m")
+	return seq
+}
+
+func TestRainbowParams(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"rainbow:freq=0.1:spread=3:seed=42:direction=vertical", false},
+		{"rainbow:seed=random", false},
+		{"rainbow:seed=hash", true},
+		{"rainbow:spread=0", true},
+		{"rainbow:freq=NaN", true},
+		{"rainbow:spread=+Inf", true},
+		{"rainbow:seed=soon", true},
+		{"rainbow:direction=sideways", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			_, err := decoration.Parse(tc.spec, decoration.ProfileTrueColor)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tc.spec, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+)
+
+// pen is what a character is written with: SGR attributes and colors as
+// the profile writes them. The zero pen is the terminal default.
+type pen struct {
+	attrs  Attr
+	fg, bg StyleColor
+}
+
+// sequence returns the SGR sequence that turns on p, or "" for the zero
+// pen.
+func (p pen) sequence() string {
+	var w sgrWriter
+	w.setPen(p)
+	return string(w.buf)
+}
+
+// Parts of the attributes set by an sgrWriter that escape sequences from
+// elsewhere may have changed.
+const (
+	staleAttrs uint8 = 1 << iota
+	staleFg
+	staleBg
+)
+
+// sgrWriter writes text in which every character has a pen. Rather than
+// setting and resetting the attributes around each character, it writes
+// only the SGR parameters that change from one character to the next.
+//
+// Escape sequences from the message or from earlier decorators are passed
+// through. The writer follows the pen that their SGR sequences set and
+// draws every character over it, so that unstyled text such as spaces
+// gets that pen back rather than a reset.
+type sgrWriter struct {
+	buf   []byte
+	base  pen   // the pen set by the escape sequences passed through
+	cur   pen   // the pen the output has set
+	stale uint8 // parts of cur that the escape sequences left unknown
+}
+
+// decorate writes input with the pen that penFor returns for each rune,
+// given the text after it, passing escape sequences through. The zero pen
+// leaves a rune as the escape sequences before it left it.
+func decorate(input []byte, penFor func(r rune, rest string) pen) []byte {
+	w := newSGRWriter(len(input))
+	text := string(input)
+	for len(text) > 0 {
+		if text[0] == ansi.Esc {
+			// keep escape sequences from earlier decorators intact
+			n := ansi.EscapeLen(text)
+			w.writeEscape(text[:n])
+			text = text[n:]
+			continue
+		}
+		rn, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+		w.writeRune(rn, penFor(rn, text))
+	}
+	return w.bytes()
+}
+
+// newSGRWriter returns a writer for decorating n bytes of text.
+func newSGRWriter(n int) sgrWriter {
+	return sgrWriter{buf: make([]byte, 0, 2*n)}
+}
+
+// writeRune writes r with pen p over the pen of the escape sequences; the
+// zero pen writes r as they left it.
+func (w *sgrWriter) writeRune(r rune, p pen) {
+	w.setPen(p.over(w.base))
+	w.buf = utf8.AppendRune(w.buf, r)
+}
+
+// writeEscape passes the escape sequence seq through.
+func (w *sgrWriter) writeEscape(seq string) {
+	w.buf = append(w.buf, seq...)
+	if !ansi.IsSGR(seq) {
+		return
+	}
+
+	r := sgrReader{s: seq[2 : len(seq)-1], sep: ";"}
+	for {
+		param, ok := r.next()
+		if !ok {
+			break
+		}
+		if !param.valid {
+			w.stale |= staleAttrs | staleFg | staleBg
+			continue
+		}
+		if param.n == 0 {
+			w.stale = 0
+		}
+		w.base.apply(param)
+		w.cur.apply(param)
+	}
+}
+
+// bytes puts back the pen of the escape sequences and returns the text
+// written.
+func (w *sgrWriter) bytes() []byte {
+	w.setPen(w.base)
+	return w.buf
+}
+
+// over returns the pen that p draws with over base: the attributes of
+// both, and the colors of p where it sets them.
+func (p pen) over(base pen) pen {
+	base.attrs |= p.attrs
+	if p.fg.kind != colorUnset {
+		base.fg = p.fg
+	}
+	if p.bg.kind != colorUnset {
+		base.bg = p.bg
+	}
+	return base
+}
+
+// setPen writes the SGR sequence that changes the attributes from the
+// current pen to p, if there is anything to change.
+func (w *sgrWriter) setPen(p pen) {
+	if p == w.cur && w.stale == 0 {
+		return
+	}
+	if p == (pen{}) {
+		if w.cur != (pen{}) {
+			w.buf = append(w.buf, "\x1b[0m"...)
+		}
+		w.cur, w.stale = pen{}, 0
+		return
+	}
+
+	start := len(w.buf)
+	w.buf = append(w.buf, "\x1b["...)
+	params := len(w.buf)
+
+	on := p.attrs &^ w.cur.attrs
+	if w.stale&staleAttrs != 0 {
+		on = p.attrs
+	}
+	// some attributes share the parameter that turns them off, so turn
+	// back on those that p keeps
+	off := w.cur.attrs &^ p.attrs
+	for _, at := range attrs {
+		if off&at.attr == 0 {
+			continue
+		}
+		w.buf = appendParam(w.buf, params, at.off)
+		for _, other := range attrs {
+			if other.off == at.off {
+				off &^= other.attr
+				on |= other.attr & p.attrs
+			}
+		}
+	}
+	for _, at := range attrs {
+		if on&at.attr != 0 {
+			w.buf = appendParam(w.buf, params, at.param)
+		}
+	}
+
+	w.buf = appendColor(w.buf, params, w.cur.fg, p.fg, w.stale&staleFg != 0, false)
+	w.buf = appendColor(w.buf, params, w.cur.bg, p.bg, w.stale&staleBg != 0, true)
+
+	if len(w.buf) == params {
+		w.buf = w.buf[:start]
+	} else {
+		w.buf = append(w.buf, 'm')
+	}
+	w.cur, w.stale = p, 0
+}
+
+// appendColor appends the parameters that change a color from cur to c,
+// or set c again if stale.
+func appendColor(b []byte, params int, cur, c StyleColor, stale, bg bool) []byte {
+	switch {
+	case c == cur && (!stale || c.kind == colorUnset):
+		return b
+	case c.kind == colorUnset:
+		if bg {
+			return appendParam(b, params, "49")
+		}
+		return appendParam(b, params, "39")
+	}
+	if len(b) > params {
+		b = append(b, ';')
+	}
+	return c.appendParams(b, bg)
+}
+
+// appendParam appends an SGR parameter, separated from those after
+// offset params.
+func appendParam(b []byte, params int, param string) []byte {
+	if len(b) > params {
+		b = append(b, ';')
+	}
+	return append(b, param...)
+}
+
+// sgrParam is a parameter of an SGR sequence. The extended colors 38 and
+// 48 come with the color they set.
+type sgrParam struct {
+	n     int
+	color StyleColor
+	valid bool
+}
+
+// sgrReader reads the parameters of an SGR sequence, or the
+// subparameters of one, separated by sep.
+type sgrReader struct {
+	s    string
+	sep  string
+	done bool
+}
+
+// field returns the next parameter as text.
+func (r *sgrReader) field() (string, bool) {
+	if r.done {
+		return "", false
+	}
+	field, rest, more := strings.Cut(r.s, r.sep)
+	r.s, r.done = rest, !more
+	return field, true
+}
+
+// int returns the next parameter as a number; an empty one is 0.
+func (r *sgrReader) int() (int, bool) {
+	field, ok := r.field()
+	if !ok {
+		return 0, false
+	}
+	if field == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(field)
+	return n, err == nil
+}
+
+// next returns the next parameter, reading the arguments of an extended
+// color from the parameters after it (38;5;n) or from its subparameters
+// (38:5:n).
+func (r *sgrReader) next() (sgrParam, bool) {
+	field, ok := r.field()
+	if !ok {
+		return sgrParam{}, false
+	}
+	head, sub, colon := strings.Cut(field, ":")
+	n, err := strconv.Atoi(head)
+	if head == "" {
+		n, err = 0, nil
+	}
+	p := sgrParam{n: n, valid: err == nil}
+	if p.valid && (n == 38 || n == 48) {
+		if colon {
+			args := sgrReader{s: sub, sep: ":"}
+			p.color, p.valid = args.color(strings.Count(sub, ":") == 4)
+		} else {
+			p.color, p.valid = r.color(false)
+		}
+	}
+	return p, true
+}
+
+// color reads the arguments of an extended color: 5 and an index into the
+// 256-color palette, or 2 and the red, green and blue channels, preceded
+// by a color space that is ignored if colorSpace is set.
+func (r *sgrReader) color(colorSpace bool) (StyleColor, bool) {
+	kind, ok := r.int()
+	if !ok {
+		return StyleColor{}, false
+	}
+	switch kind {
+	case 5:
+		i, ok := r.int()
+		if !ok || i < 0 || i > 255 {
+			return StyleColor{}, false
+		}
+		return StyleColor{kind: color256, index: uint8(i)}, true
+	case 2:
+		if colorSpace {
+			r.field()
+		}
+		var rgb [3]int
+		for i := range rgb {
+			if rgb[i], ok = r.int(); !ok || rgb[i] < 0 || rgb[i] > 255 {
+				return StyleColor{}, false
+			}
+		}
+		return RGBColor(Color{uint8(rgb[0]), uint8(rgb[1]), uint8(rgb[2])}), true
+	}
+	return StyleColor{}, false
+}
+
+// apply changes the pen as the SGR parameter does. Parameters a pen does
+// not hold, such as hidden text or fonts, are ignored.
+func (p *pen) apply(param sgrParam) {
+	switch n := param.n; {
+	case !param.valid:
+	case n == 0:
+		*p = pen{}
+	case n >= 30 && n <= 37:
+		p.fg = ANSIColor(n - 30)
+	case n >= 90 && n <= 97:
+		p.fg = ANSIColor(n - 90 + 8)
+	case n == 38:
+		p.fg = param.color
+	case n == 39:
+		p.fg = StyleColor{}
+	case n >= 40 && n <= 47:
+		p.bg = ANSIColor(n - 40)
+	case n >= 100 && n <= 107:
+		p.bg = ANSIColor(n - 100 + 8)
+	case n == 48:
+		p.bg = param.color
+	case n == 49:
+		p.bg = StyleColor{}
+	default:
+		code := strconv.Itoa(n)
+		for _, at := range attrs {
+			if at.param == code {
+				p.attrs |= at.attr
+			} else if at.off == code {
+				p.attrs &^= at.attr
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/cowsay"
+	"github.com/xogas/cowsay-go/internal/ansi"
+)
+
+func TestSGRWriter(t *testing.T) {
+	bold := pen{attrs: AttrBold}
+	red := pen{fg: ANSIColor(1)}
+	tests := []struct {
+		name  string
+		write func(w *sgrWriter)
+		want  string
+	}{
+		{
+			name: "one sequence per run",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', bold)
+				w.writeRune('b', bold)
+				w.writeRune(' ', pen{})
+				w.writeRune('c', bold)
+			},
+			want: "\x1b[1mab\x1b[0m \x1b[1mc\x1b[0m",
+		},
+		{
+			name: "only changes",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', bold)
+				w.writeRune('b', pen{attrs: AttrBold | AttrUnderline, fg: ANSIColor(9)})
+				w.writeRune('c', pen{attrs: AttrUnderline, fg: ANSIColor(9)})
+				w.writeRune('d', pen{attrs: AttrUnderline})
+			},
+			want: "\x1b[1ma\x1b[4;91mb\x1b[22mc\x1b[39md\x1b[0m",
+		},
+		{
+			name: "turning off bold keeps dim",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', pen{attrs: AttrBold | AttrDim})
+				w.writeRune('b', pen{attrs: AttrDim})
+			},
+			want: "\x1b[1;2ma\x1b[22;2mb\x1b[0m",
+		},
+		{
+			name: "colors",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', pen{fg: RGBColor(Color{1, 2, 3}), bg: StyleColor{kind: color256, index: 21}})
+				w.writeRune('b', pen{bg: StyleColor{kind: color256, index: 21}})
+			},
+			want: "\x1b[38;2;1;2;3;48;5;21ma\x1b[39mb\x1b[0m",
+		},
+		{
+			name: "set again after a reset",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', bold)
+				w.writeEscape("\x1b[0m")
+				w.writeRune('b', bold)
+			},
+			want: "\x1b[1ma\x1b[0m\x1b[1mb\x1b[0m",
+		},
+		{
+			name: "set again after a change",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', red)
+				w.writeEscape("\x1b[32m")
+				w.writeRune('b', red)
+			},
+			want: "\x1b[31ma\x1b[32m\x1b[31mb\x1b[32m",
+		},
+		{
+			name: "changes to other attributes kept",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', bold)
+				w.writeEscape("\x1b[38;5;1m")
+				w.writeRune('b', bold)
+				w.writeEscape("\x1b[48:2::1:2:3m")
+				w.writeRune('c', bold)
+			},
+			want: "\x1b[1ma\x1b[38;5;1mb\x1b[48:2::1:2:3mc\x1b[22m",
+		},
+		{
+			name: "other escapes passed through",
+			write: func(w *sgrWriter) {
+				w.writeRune('a', red)
+				w.writeEscape("\x1b]8;;http://x\x1b\\")
+				w.writeRune('b', red)
+			},
+			want: "\x1b[31ma\x1b]8;;http://x\x1b\\b\x1b[0m",
+		},
+		{
+			name: "pen of the escapes put back",
+			write: func(w *sgrWriter) {
+				w.writeEscape("\x1b[31;4m")
+				w.writeRune('a', bold)
+				w.writeRune(' ', pen{})
+				w.writeRune('b', pen{attrs: AttrDim, fg: ANSIColor(2)})
+				w.writeRune(' ', pen{})
+				w.writeEscape("\x1b[0m")
+				w.writeRune('c', bold)
+			},
+			want: "\x1b[31;4m\x1b[1ma\x1b[22m \x1b[2;32mb\x1b[22;31m \x1b[0m\x1b[1mc\x1b[0m",
+		},
+		{
+			name: "nothing to reset",
+			write: func(w *sgrWriter) {
+				w.writeEscape("\x1b[1m")
+				w.writeRune('a', pen{})
+			},
+			want: "\x1b[1ma",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var w sgrWriter
+			tc.write(&w)
+			if got := string(w.bytes()); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// dragon renders the dragon cow, a large piece of art with short runs of
+// characters.
+func dragon(tb testing.TB) []byte {
+	tb.Helper()
+	out, err := cowsay.NewCow("dragon", "", cowsay.InBinary).Render("The quick brown fox jumps over the lazy dog")
+	if err != nil {
+		tb.Fatalf("Render failed: %v", err)
+	}
+	return out
+}
+
+// stripEscapes removes the escape sequences from s.
+func stripEscapes(s string) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		if s[0] == ansi.Esc {
+			s = s[ansi.EscapeLen(s):]
+			continue
+		}
+		b.WriteByte(s[0])
+		s = s[1:]
+	}
+	return b.String()
+}
+
+// TestOutputSize guards against the decorated dragon growing: each size is
+// the most bytes a decorator may write for the 1063 bytes of plain text.
+func TestOutputSize(t *testing.T) {
+	plain := dragon(t)
+	tests := []struct {
+		spec    string
+		profile Profile
+		max     int
+	}{
+		{"rainbow", ProfileTrueColor, 8419},
+		{"rainbow", Profile256, 5742},
+		{"rainbow", Profile16, 2697},
+		{"blob", ProfileTrueColor, 2191},
+		{"rainbow,blob", ProfileTrueColor, 8983},
+		{"rainbow,blob", Profile16, 3261},
+		{"gradient:stops=#ff0000-#0000ff", ProfileTrueColor, 8539},
+		{"gradient:stops=#ff0000-#0000ff", Profile256, 3257},
+		{"palette", Profile256, 3120},
+		{"style:attrs=underline:fg=yellow:bg=blue", ProfileTrueColor, 3037},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec+"/"+tc.profile.String(), func(t *testing.T) {
+			p, err := Parse(tc.spec, tc.profile)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.spec, err)
+			}
+			got := p.Decorate(plain)
+			if len(got) > tc.max {
+				t.Errorf("%q wrote %d bytes, want at most %d", tc.spec, len(got), tc.max)
+			}
+			if text := stripEscapes(string(got)); text != string(plain) {
+				t.Errorf("%q changed the text to %q", tc.spec, text)
+			}
+		})
+	}
+}
+
+func BenchmarkDecorators(b *testing.B) {
+	plain := dragon(b)
+	for _, spec := range []string{"rainbow", "blob", "rainbow,blob", "gradient:stops=#ff0000-#0000ff", "palette:mode=chars"} {
+		for _, profile := range []Profile{ProfileTrueColor, Profile256, Profile16} {
+			b.Run(spec+"/"+profile.String(), func(b *testing.B) {
+				p, err := Parse(spec, profile)
+				if err != nil {
+					b.Fatalf("Parse(%q) failed: %v", spec, err)
+				}
+				b.SetBytes(int64(len(plain)))
+				var out []byte
+				for b.Loop() {
+					out = p.Decorate(plain)
+				}
+				b.ReportMetric(float64(len(out))/float64(len(plain)), "size/plain")
+			})
+		}
+	}
+}
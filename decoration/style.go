@@ -0,0 +1,346 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/xogas/cowsay-go/internal/ansi"
+)
+
+// Attr is a set of SGR text attributes.
+type Attr uint16
+
+// The attributes a Style can set.
+const (
+	AttrBold Attr = 1 << iota
+	AttrDim
+	AttrItalic
+	AttrUnderline
+	AttrBlink
+	AttrInverse
+	AttrConceal
+	AttrStrike
+)
+
+// attrs lists the attributes with their names and the SGR parameters that
+// turn them on and off, in the order their parameters are written.
+var attrs = []struct {
+	attr  Attr
+	name  string
+	param string
+	off   string
+}{
+	{AttrBold, "bold", "1", "22"},
+	{AttrDim, "dim", "2", "22"},
+	{AttrItalic, "italic", "3", "23"},
+	{AttrUnderline, "underline", "4", "24"},
+	{AttrBlink, "blink", "5", "25"},
+	{AttrInverse, "inverse", "7", "27"},
+	{AttrConceal, "conceal", "8", "28"},
+	{AttrStrike, "strike", "9", "29"},
+}
+
+// String returns the names of the attributes in a joined by "+", or
+// "none" if a is empty.
+func (a Attr) String() string {
+	var names []string
+	for _, at := range attrs {
+		if a&at.attr != 0 {
+			names = append(names, at.name)
+			a &^= at.attr
+		}
+	}
+	if a != 0 {
+		names = append(names, fmt.Sprintf("Attr(%#x)", uint16(a)))
+	}
+	if names == nil {
+		return "none"
+	}
+	return strings.Join(names, "+")
+}
+
+// ParseAttr returns the attribute with the given name.
+func ParseAttr(name string) (Attr, error) {
+	for _, at := range attrs {
+		if at.name == name {
+			return at.attr, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown attribute %q", name)
+}
+
+// colorNames are the names of the 16 ANSI colors; the bright ones are
+// prefixed with "bright-".
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// StyleColor is the foreground or background color of a Style: one of the
+// 16 ANSI colors, which terminals let users theme, or an RGB color. The
+// zero value leaves the color as it is.
+type StyleColor struct {
+	kind  colorKind
+	index uint8
+	rgb   Color
+}
+
+// colorKind tells which field of a StyleColor holds the color.
+type colorKind uint8
+
+const (
+	colorUnset colorKind = iota
+	colorANSI
+	color256 // an index into the xterm 256-color palette
+	colorRGB
+)
+
+// ANSIColor returns ANSI color i, which runs from 0 (black) to 15 (bright
+// white). It panics if i is out of range.
+func ANSIColor(i int) StyleColor {
+	if i < 0 || i > 15 {
+		panic(fmt.Sprintf("decoration: ANSI color %d out of range", i))
+	}
+	return StyleColor{kind: colorANSI, index: uint8(i)}
+}
+
+// RGBColor returns the RGB color c, which is limited to the color profile
+// when written.
+func RGBColor(c Color) StyleColor {
+	return StyleColor{kind: colorRGB, rgb: c}
+}
+
+// ParseStyleColor parses the name of an ANSI color, such as red or
+// bright-blue, or a hex color as accepted by ParseHexColor.
+func ParseStyleColor(s string) (StyleColor, error) {
+	name, bright := strings.CutPrefix(s, "bright-")
+	for i, n := range colorNames {
+		if n == name {
+			if bright {
+				i += 8
+			}
+			return ANSIColor(i), nil
+		}
+	}
+	c, err := ParseHexColor(s)
+	if err != nil {
+		return StyleColor{}, fmt.Errorf("invalid color %q", s)
+	}
+	return RGBColor(c), nil
+}
+
+// resolve returns the color as the profile writes it.
+func (c StyleColor) resolve(profile Profile) StyleColor {
+	switch {
+	case profile == ProfileNone:
+		return StyleColor{}
+	case c.kind == colorRGB:
+		return profile.quantize(c.rgb)
+	}
+	return c
+}
+
+// appendParams appends the SGR parameters that set the color, as a
+// background color if bg is set.
+func (c StyleColor) appendParams(b []byte, bg bool) []byte {
+	base := 30
+	if bg {
+		base = 40
+	}
+	switch c.kind {
+	case colorANSI:
+		i := int(c.index)
+		if i >= 8 {
+			base, i = base+60, i-8
+		}
+		return strconv.AppendInt(b, int64(base+i), 10)
+	case color256:
+		b = strconv.AppendInt(b, int64(base+8), 10)
+		b = append(b, ";5;"...)
+		return strconv.AppendInt(b, int64(c.index), 10)
+	case colorRGB:
+		b = strconv.AppendInt(b, int64(base+8), 10)
+		b = append(b, ";2;"...)
+		b = strconv.AppendInt(b, int64(c.rgb.R), 10)
+		b = append(b, ';')
+		b = strconv.AppendInt(b, int64(c.rgb.G), 10)
+		b = append(b, ';')
+		return strconv.AppendInt(b, int64(c.rgb.B), 10)
+	}
+	return b
+}
+
+// Style is a combination of SGR attributes and colors.
+type Style struct {
+	Attrs Attr
+	Fg    StyleColor
+	Bg    StyleColor
+}
+
+// ParseStyle parses a comma-separated list of attribute names and fg= or
+// bg= colors, as in "underline,fg=yellow,bg=blue".
+func ParseStyle(spec string) (Style, error) {
+	var s Style
+	seen := map[string]bool{}
+	for item := range strings.SplitSeq(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			key, value = "attrs", item
+		} else if seen[key] {
+			return Style{}, fmt.Errorf("duplicate parameter %q", key)
+		}
+		seen[key] = true
+		if err := s.set(key, value); err != nil {
+			return Style{}, err
+		}
+	}
+	return s, nil
+}
+
+// set applies one parameter of the style: fg, bg, or attrs with a single
+// attribute name.
+func (s *Style) set(key, value string) error {
+	switch key {
+	case "fg", "bg":
+		c, err := ParseStyleColor(value)
+		if err != nil {
+			return err
+		}
+		if key == "fg" {
+			s.Fg = c
+		} else {
+			s.Bg = c
+		}
+	case "attrs":
+		a, err := ParseAttr(value)
+		if err != nil {
+			return err
+		}
+		s.Attrs |= a
+	default:
+		return fmt.Errorf("unknown parameter %q", key)
+	}
+	return nil
+}
+
+// Sequence returns the SGR sequence that turns on the style, with colors
+// limited to the profile. It returns "" if there is nothing to turn on.
+func (s Style) Sequence(profile Profile) string {
+	return s.pen(profile).sequence()
+}
+
+// pen returns the pen that writes the style with the profile.
+func (s Style) pen(profile Profile) pen {
+	if profile == ProfileNone {
+		return pen{}
+	}
+	return pen{attrs: s.Attrs, fg: s.Fg.resolve(profile), bg: s.Bg.resolve(profile)}
+}
+
+// NewStyle returns a decorator that applies the style to every run of
+// visible characters, the spaces between them on a line included, with
+// colors limited to the profile. Spaces at the start and end of a line are
+// left alone; limit the style with InRegions to keep the balloon padding
+// and the gaps in the cow blank as well. With ProfileNone it leaves the
+// input unchanged.
+func NewStyle(s Style, profile Profile) Decorator {
+	if profile == ProfileNone {
+		return Pipeline{}
+	}
+	return style{pen: s.pen(profile), spaces: true}
+}
+
+// style is the "style" decorator. Its parameters are attrs, attribute
+// names joined by "+" as in attrs=bold+underline, and the colors fg and
+// bg.
+type style struct {
+	pen    pen
+	spaces bool // style the spaces within a run too
+}
+
+func newStyle(params Params, profile Profile) (Decorator, error) {
+	var s Style
+	for key, value := range params {
+		if key != "attrs" {
+			if err := s.set(key, value); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for name := range strings.SplitSeq(value, "+") {
+			if err := s.set(key, name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return NewStyle(s, profile), nil
+}
+
+// Decorate implements Decorator.
+func (s style) Decorate(input []byte) []byte {
+	// inRun is set within a run, whose spaces so far are those with at
+	// least end bytes of text after them
+	inRun, end := false, 0
+	return decorate(input, func(rn rune, rest string) pen {
+		switch {
+		case !unicode.IsSpace(rn):
+			inRun, end = s.spaces, len(rest)
+			return s.pen
+		case !inRun || rn == '\n':
+			inRun = false
+			return pen{}
+		case len(rest) >= end:
+			return s.pen
+		}
+		if end, inRun = runContinues(rest); inRun {
+			return s.pen
+		}
+		return pen{}
+	})
+}
+
+// runContinues reports whether text has a visible character before the
+// end of the line, and how many bytes of text are left from there on.
+func runContinues(text string) (int, bool) {
+	for len(text) > 0 {
+		if text[0] == ansi.Esc {
+			text = text[ansi.EscapeLen(text):]
+			continue
+		}
+		rn, size := utf8.DecodeRuneInString(text)
+		switch {
+		case rn == '\n':
+			return 0, false
+		case !unicode.IsSpace(rn):
+			return len(text), true
+		}
+		text = text[size:]
+	}
+	return 0, false
+}
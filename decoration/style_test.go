@@ -0,0 +1,204 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package decoration_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func TestStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		profile decoration.Profile
+		msg     string
+		wantMsg string
+	}{
+		{
+			name:    "one sequence per run",
+			spec:    "underline,fg=yellow,bg=blue",
+			profile: decoration.ProfileTrueColor,
+			msg:     "ab cd\n e\n",
+			wantMsg: "\x1b[4;33;44mab cd\x1b[0m\n \x1b[4;33;44me\x1b[0m\n",
+		},
+		{
+			name:    "spaces within a run",
+			spec:    "underline",
+			profile: decoration.ProfileTrueColor,
+			msg:     "  hello \t world  \n\tx \n",
+			wantMsg: "  \x1b[4mhello \t world\x1b[0m  \n\t\x1b[4mx\x1b[0m \n",
+		},
+		{
+			name:    "escapes within a run",
+			spec:    "bg=blue",
+			profile: decoration.ProfileTrueColor,
+			msg:     "a \x1b[31m b\x1b[0m c",
+			wantMsg: "\x1b[44ma \x1b[31m b\x1b[0m\x1b[44m c\x1b[0m",
+		},
+		{
+			name:    "all attributes",
+			spec:    "strike,conceal,inverse,blink,underline,italic,dim,bold",
+			profile: decoration.Profile16,
+			msg:     "x",
+			wantMsg: "\x1b[1;2;3;4;5;7;8;9mx\x1b[0m",
+		},
+		{
+			name:    "bright and hex colors",
+			spec:    "fg=bright-red,bg=#0000ff",
+			profile: decoration.Profile256,
+			msg:     "x",
+			wantMsg: "\x1b[91;48;5;21mx\x1b[0m",
+		},
+		{
+			name:    "no colors",
+			spec:    "italic,fg=red",
+			profile: decoration.ProfileNone,
+			msg:     "x",
+			wantMsg: "x",
+		},
+		{
+			name:    "nothing to set",
+			spec:    "",
+			profile: decoration.ProfileTrueColor,
+			msg:     "ab",
+			wantMsg: "ab",
+		},
+		{
+			name:    "set again after a reset",
+			spec:    "bold",
+			profile: decoration.ProfileTrueColor,
+			msg:     "\x1b[31ma\x1b[0m\x1b[32mb\x1b[0m",
+			wantMsg: "\x1b[31m\x1b[1ma\x1b[0m\x1b[32m\x1b[1mb\x1b[0m",
+		},
+		{
+			name:    "run across other escapes",
+			spec:    "bold",
+			profile: decoration.ProfileTrueColor,
+			msg:     "a\x1b[31mb",
+			wantMsg: "\x1b[1ma\x1b[31mb\x1b[22m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := decoration.ParseStyle(tc.spec)
+			if err != nil {
+				t.Fatalf("ParseStyle(%q) failed: %v", tc.spec, err)
+			}
+			if got := decoration.NewStyle(s, tc.profile).Decorate([]byte(tc.msg)); tc.wantMsg != string(got) {
+				t.Fatalf("style %q on %q = %q, want %q", tc.spec, tc.msg, got, tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    decoration.Attr
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"bold, underline", decoration.AttrBold | decoration.AttrUnderline, false},
+		{"dim,fg=white", decoration.AttrDim, false},
+		{"shiny", 0, true},
+		{"fg=beige", 0, true},
+		{"fg=red,fg=blue", 0, true},
+		{"size=2", 0, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			s, err := decoration.ParseStyle(tc.spec)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParseStyle(%q) error = %v, want error %v", tc.spec, err, tc.wantErr)
+			}
+			if s.Attrs != tc.want {
+				t.Fatalf("ParseStyle(%q).Attrs = %v, want %v", tc.spec, s.Attrs, tc.want)
+			}
+		})
+	}
+}
+
+func TestAttrString(t *testing.T) {
+	tests := []struct {
+		attr decoration.Attr
+		want string
+	}{
+		{0, "none"},
+		{decoration.AttrItalic, "italic"},
+		{decoration.AttrStrike | decoration.AttrBold, "bold+strike"},
+		{0x100, "Attr(0x100)"},
+	}
+
+	for _, tc := range tests {
+		if got := tc.attr.String(); got != tc.want {
+			t.Errorf("Attr(%#x).String() = %q, want %q", uint8(tc.attr), got, tc.want)
+		}
+	}
+}
+
+func TestStyleParams(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{"style:attrs=bold+italic:fg=#ff0000", "\x1b[1;3;38;2;255;0;0mx\x1b[0m", false},
+		{"style:bg=cyan", "\x1b[46mx\x1b[0m", false},
+		{"style", "x", false},
+		{"style:attrs=bold+shiny", "", true},
+		{"style:fg=beige", "", true},
+		{"style:size=2", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			p, err := decoration.Parse(tc.spec, decoration.ProfileTrueColor)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tc.spec, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := p.Decorate([]byte("x")); string(got) != tc.want {
+				t.Fatalf("%q on %q = %q, want %q", tc.spec, "x", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStyleInRegions(t *testing.T) {
+	grid := renderGrid(t, "hello world")
+	p, err := decoration.Parse("style:attrs=underline:region=text", decoration.ProfileTrueColor)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	got := string(decoration.DecorateGrid(grid, p).Bytes())
+	if want := "< \x1b[4mhello world\x1b[0m >\n"; !strings.Contains(got, want) {
+		t.Fatalf("DecorateGrid = %q, want it to contain %q", got, want)
+	}
+}
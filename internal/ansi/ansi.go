@@ -0,0 +1,79 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package ansi scans the terminal escape sequences found in cowsay output.
+package ansi
+
+import "strings"
+
+const (
+	// Esc starts every escape sequence.
+	Esc = '\x1b'
+	bel = '\a'
+)
+
+// EscapeLen returns the length of the escape sequence at the start of s,
+// which must begin with ESC. It recognises CSI sequences (ESC [ ... final),
+// the string sequences OSC, DCS, SOS, PM and APC terminated by BEL or ST,
+// and two-byte escapes. An unterminated sequence runs to the end of s.
+func EscapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		// parameter and intermediate bytes, then a final byte
+		i := 2
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x3F {
+			i++
+		}
+		if i < len(s) && s[i] >= 0x40 && s[i] <= 0x7E {
+			i++
+		}
+		return i
+	case ']', 'P', 'X', '^', '_':
+		for i := 2; i < len(s); i++ {
+			switch {
+			case s[i] == bel && s[1] == ']':
+				return i + 1
+			case s[i] == Esc && i+1 < len(s) && s[i+1] == '\\':
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+	return 2
+}
+
+// IsSGR reports whether the escape sequence seq sets graphic rendition
+// (colors, bold, ...).
+func IsSGR(seq string) bool {
+	return len(seq) >= 3 && seq[1] == '[' && seq[len(seq)-1] == 'm'
+}
+
+// SGRResets reports whether the SGR sequence seq starts by resetting all
+// attributes, as ESC[m and ESC[0;31m do.
+func SGRResets(seq string) bool {
+	params := seq[2 : len(seq)-1]
+	first, _, _ := strings.Cut(params, ";")
+	return first == "" || strings.Trim(first, "0") == ""
+}
@@ -0,0 +1,77 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ansi
+
+import "testing"
+
+func TestEscapeLen(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"lone escape", "\x1b", 1},
+		{"sgr", "\x1b[1;31mx", 7},
+		{"sgr without parameters", "\x1b[mx", 3},
+		{"unterminated csi", "\x1b[31", 4},
+		{"osc with bel", "\x1b]0;title\ax", 10},
+		{"osc with st", "\x1b]8;;http://x\x1b\\x", 15},
+		{"unterminated osc", "\x1b]0;title", 9},
+		{"two-byte escape", "\x1bcx", 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EscapeLen(tc.s); got != tc.want {
+				t.Fatalf("EscapeLen(%q) = %d, want %d", tc.s, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSGRResets(t *testing.T) {
+	tests := []struct {
+		seq  string
+		sgr  bool
+		want bool
+	}{
+		{"\x1b[m", true, true},
+		{"\x1b[0m", true, true},
+		{"\x1b[00;31m", true, true},
+		{"\x1b[31m", true, false},
+		{"\x1b[1;0m", true, false},
+		{"\x1b[2J", false, false},
+	}
+
+	for _, tc := range tests {
+		if got := IsSGR(tc.seq); got != tc.sgr {
+			t.Errorf("IsSGR(%q) = %t, want %t", tc.seq, got, tc.sgr)
+		}
+		if !tc.sgr {
+			continue
+		}
+		if got := SGRResets(tc.seq); got != tc.want {
+			t.Errorf("SGRResets(%q) = %t, want %t", tc.seq, got, tc.want)
+		}
+	}
+}
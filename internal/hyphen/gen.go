@@ -0,0 +1,303 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build ignore
+
+// gen extracts the hyphenation patterns in patterns/ from the compiled .hyb
+// files that Android and Chromium ship. Those files are built from the
+// hyph-utf8 TeX patterns by Android's mk_hyb_file.py, which folds the
+// exception lists into the patterns; gen walks the packed trie and writes
+// the patterns back out in the hyph-utf8 text format.
+//
+// Usage:
+//
+//	go run gen.go -hyb directory [-output patterns]
+//
+// The directory must hold hyph-en-us.hyb, hyph-de-1996.hyb and hyph-fr.hyb,
+// such as the hyphen-data directory of a Chromium build.
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	hyb    = flag.String("hyb", "", "directory holding the .hyb files")
+	output = flag.String("output", "patterns", "output directory")
+)
+
+// languages maps the pattern files to write to their .hyb sources and the
+// notices of the original TeX patterns.
+var languages = []struct {
+	name, source string
+	notice       []string
+}{
+	{"en-us", "hyph-en-us.hyb", []string{
+		"Hyphenation patterns for American English, from hyph-en-us.tex by",
+		"Donald E. Knuth and Frank M. Liang, as shipped with hyph-utf8.",
+		"Unlimited copying and redistribution of the original file are",
+		"permitted; this derived file is not named hyphen.tex.",
+	}},
+	{"de", "hyph-de-1996.hyb", []string{
+		"Hyphenation patterns for German in the 1996 orthography, from",
+		"hyph-de-1996.tex as shipped with hyph-utf8.",
+		"",
+		"Copyright (c) 2013-2017",
+		"Stephan Hennig, Werner Lemberg, Guenter Milde, Sander van Geloven,",
+		"Georg Pfeiffer, Gisbert W. Selke, Tobias Wendorf",
+		"",
+		"Permission is hereby granted, free of charge, to any person obtaining a copy",
+		"of this software and associated documentation files (the \"Software\"), to deal",
+		"in the Software without restriction, including without limitation the rights",
+		"to use, copy, modify, merge, publish, distribute, sublicense, and/or sell",
+		"copies of the Software, and to permit persons to whom the Software is",
+		"furnished to do so, subject to the following conditions:",
+		"",
+		"The above copyright notice and this permission notice shall be included in",
+		"all copies or substantial portions of the Software.",
+		"",
+		"THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR",
+		"IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,",
+		"FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL THE",
+		"AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER",
+		"LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,",
+		"OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN",
+		"THE SOFTWARE.",
+	}},
+	{"fr", "hyph-fr.hyb", []string{
+		"Hyphenation patterns for French, from hyph-fr.tex as shipped with",
+		"hyph-utf8.",
+		"",
+		"Copyright (C) 1994-2002 Daniel Flipo, Bernard Gaulle.",
+		"",
+		"Permission is hereby granted, free of charge, to any person obtaining",
+		"a copy of this software and associated documentation files (the",
+		"\"Software\"), to deal in the Software without restriction, including",
+		"without limitation the rights to use, copy, modify, merge, publish,",
+		"distribute, sublicense, and/or sell copies of the Software, and to",
+		"permit persons to whom the Software is furnished to do so, subject to",
+		"the following conditions:",
+		"",
+		"The above copyright notice and this permission notice shall be",
+		"included in all copies or substantial portions of the Software.",
+		"",
+		"THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND,",
+		"EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF",
+		"MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND",
+		"NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS",
+		"BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN",
+		"ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN",
+		"CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE",
+		"SOFTWARE.",
+	}},
+}
+
+func main() {
+	flag.Parse()
+	if *hyb == "" {
+		log.Fatal("missing -hyb directory")
+	}
+
+	for _, lang := range languages {
+		data, err := os.ReadFile(filepath.Join(*hyb, lang.source))
+		if err != nil {
+			log.Fatal(err)
+		}
+		patterns, err := decode(data)
+		if err == nil {
+			patterns, err = checkExceptions(patterns)
+		}
+		if err != nil {
+			log.Fatalf("%s: %v", lang.source, err)
+		}
+		if err := write(filepath.Join(*output, lang.name+".pat"), lang.source, lang.notice, patterns); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// hybMagic starts every .hyb file.
+const hybMagic = 0x62ad7968
+
+// decode returns the patterns of a .hyb file, sorted.
+func decode(data []byte) ([]string, error) {
+	u32 := func(off uint32) uint32 {
+		return binary.LittleEndian.Uint32(data[off:])
+	}
+	if len(data) < 24 || u32(0) != hybMagic {
+		return nil, fmt.Errorf("not a .hyb file")
+	}
+	alphabetOff, trieOff, patternOff := u32(8), u32(12), u32(16)
+
+	// letters maps an alphabet code to the letter it stands for; code 0 is
+	// the word boundary
+	letters := map[uint32]rune{0: '.'}
+	addLetter := func(r rune, code uint32) {
+		if code == 0 {
+			return
+		}
+		// upper and lower case share a code: keep the lower case letter
+		if prev, ok := letters[code]; !ok || unicode.IsLower(r) && !unicode.IsLower(prev) {
+			letters[code] = r
+		}
+	}
+	switch u32(alphabetOff) {
+	case 0:
+		lo, hi := u32(alphabetOff+4), u32(alphabetOff+8)
+		for cp := lo; cp < hi; cp++ {
+			addLetter(rune(cp), uint32(data[alphabetOff+12+cp-lo]))
+		}
+	case 1:
+		n := u32(alphabetOff + 4)
+		for i := uint32(0); i < n; i++ {
+			e := u32(alphabetOff + 8 + 4*i)
+			addLetter(rune(e>>11), e&0x7ff)
+		}
+	default:
+		return nil, fmt.Errorf("unknown alphabet version %d", u32(alphabetOff))
+	}
+
+	charMask, linkShift, linkMask := u32(trieOff+4), u32(trieOff+8), u32(trieOff+12)
+	patternShift, trieSize := u32(trieOff+16), u32(trieOff+20)
+	trie := func(i uint32) uint32 { return u32(trieOff + 24 + 4*i) }
+	patternBuf := patternOff + u32(patternOff+8)
+
+	var patterns []string
+	var walk func(node uint32, word []uint32)
+	walk = func(node uint32, word []uint32) {
+		if ix := trie(node) >> patternShift; ix != 0 {
+			e := u32(patternOff + 16 + 4*ix)
+			n, shift, off := e>>26, (e>>20)&0x3f, e&0xfffff
+			// the values sit between the letters of word, right aligned
+			// with shift trailing zeros dropped
+			values := make([]byte, len(word)+1)
+			first := uint32(len(word)) + 1 - shift - n
+			copy(values[first:], data[patternBuf+off:patternBuf+off+n])
+			patterns = append(patterns, format(word, values, letters))
+		}
+		if len(word) > 1 && word[len(word)-1] == 0 {
+			// nothing follows the boundary at the end of a word
+			return
+		}
+		for c := range uint32(len(letters)) {
+			if node+c >= trieSize {
+				break
+			}
+			e := trie(node + c)
+			if e&charMask != c {
+				continue
+			}
+			next := (e & linkMask) >> linkShift
+			if next == 0 {
+				continue
+			}
+			walk(next, append(word[:len(word):len(word)], c))
+		}
+	}
+	walk(0, nil)
+	sort.Strings(patterns)
+	return patterns, nil
+}
+
+// Exceptions are compiled into patterns with the values 10 (no break) and
+// 11 (break), above any value of the patterns proper. TeX patterns only
+// have the digits 0 to 9, so they are written as 8 and 9, which works as
+// long as the patterns proper stay below 8.
+const (
+	exceptionNoBreak = 10
+	exceptionBreak   = 11
+)
+
+// checkExceptions rewrites the exception values of the patterns.
+func checkExceptions(patterns []string) ([]string, error) {
+	hasExceptions, highest := false, byte('0')
+	for _, p := range patterns {
+		for i := 0; i < len(p); i++ {
+			switch c := p[i]; {
+			case c == '0'+exceptionNoBreak || c == '0'+exceptionBreak:
+				hasExceptions = true
+			case c >= '0' && c <= '9':
+				highest = max(highest, c)
+			}
+		}
+	}
+	if !hasExceptions {
+		return patterns, nil
+	}
+	if highest >= '8' {
+		return nil, fmt.Errorf("pattern value %c clashes with the exceptions", highest)
+	}
+	r := strings.NewReplacer(string(rune('0'+exceptionNoBreak)), "8", string(rune('0'+exceptionBreak)), "9")
+	for i, p := range patterns {
+		patterns[i] = r.Replace(p)
+	}
+	return patterns, nil
+}
+
+// format writes a pattern in the TeX notation: the letters of word with the
+// nonzero values between them.
+func format(word []uint32, values []byte, letters map[uint32]rune) string {
+	var b strings.Builder
+	for i, c := range word {
+		if values[i] != 0 {
+			b.WriteByte('0' + values[i])
+		}
+		b.WriteRune(letters[c])
+	}
+	if v := values[len(word)]; v != 0 {
+		b.WriteByte('0' + v)
+	}
+	return b.String()
+}
+
+func write(name, source string, notice, patterns []string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	fmt.Fprintf(w, "%% Code generated by gen.go from %s; DO NOT EDIT.\n%%\n", source)
+	for _, line := range notice {
+		if line == "" {
+			fmt.Fprintln(w, "%")
+			continue
+		}
+		fmt.Fprintf(w, "%% %s\n", line)
+	}
+	for _, p := range patterns {
+		fmt.Fprintln(w, p)
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
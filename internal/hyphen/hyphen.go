@@ -0,0 +1,207 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package hyphen finds hyphenation points with Liang's algorithm, using the
+// TeX hyphenation patterns embedded in patterns/.
+package hyphen
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed patterns/*.pat
+var patternFiles embed.FS
+
+// languages lists the embedded patterns and the minimum number of letters
+// each language keeps before and after a hyphen.
+var languages = map[string]struct {
+	file        string
+	left, right int
+}{
+	"en-us": {"patterns/en-us.pat", 2, 3},
+	"de":    {"patterns/de.pat", 2, 2},
+	"fr":    {"patterns/fr.pat", 2, 3},
+}
+
+// aliases maps other common names to the languages above.
+var aliases = map[string]string{
+	"en":    "en-us",
+	"de-de": "de",
+	"fr-fr": "fr",
+}
+
+// Languages returns the names of the languages that can be hyphenated.
+func Languages() []string {
+	names := make([]string, 0, len(languages))
+	for name := range languages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Hyphenator finds the points where the words of one language may be
+// hyphenated.
+type Hyphenator struct {
+	// patterns maps the letters of each pattern to its values; values[i] is
+	// the value before letter i, and the last one follows the last letter
+	patterns    map[string][]byte
+	maxLen      int // letters in the longest pattern
+	left, right int
+}
+
+var (
+	mu    sync.Mutex
+	cache = make(map[string]*Hyphenator)
+)
+
+// Load returns the Hyphenator for a language such as "en-us", "de" or "fr".
+// Case and the separator between language and region do not matter.
+func Load(lang string) (*Hyphenator, error) {
+	name := strings.ReplaceAll(strings.ToLower(lang), "_", "-")
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	l, ok := languages[name]
+	if !ok {
+		return nil, fmt.Errorf("no hyphenation patterns for language %q", lang)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if h, ok := cache[name]; ok {
+		return h, nil
+	}
+	f, err := patternFiles.Open(l.file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := &Hyphenator{patterns: make(map[string][]byte), left: l.left, right: l.right}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '%' {
+			continue
+		}
+		h.add(line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", l.file, err)
+	}
+	cache[name] = h
+	return h, nil
+}
+
+// add adds a pattern in the TeX notation, such as "1na" or ".hy3p".
+func (h *Hyphenator) add(pattern string) {
+	var letters strings.Builder
+	values := []byte{0}
+	for _, r := range pattern {
+		if r >= '0' && r <= '9' {
+			values[len(values)-1] = byte(r - '0')
+			continue
+		}
+		letters.WriteRune(r)
+		values = append(values, 0)
+	}
+	h.patterns[letters.String()] = values
+	h.maxLen = max(h.maxLen, len(values)-1)
+}
+
+// Points returns the byte offsets in word where it may be broken with a
+// hyphen. Leading and trailing punctuation is ignored; words containing
+// anything other than letters and apostrophes are not hyphenated.
+func (h *Hyphenator) Points(word string) []int {
+	start := strings.IndexFunc(word, unicode.IsLetter)
+	if start < 0 {
+		return nil
+	}
+	last := strings.LastIndexFunc(word, unicode.IsLetter)
+	_, size := utf8.DecodeRuneInString(word[last:])
+	end := last + size
+
+	// the letters of the word, lower case and between boundary dots, and
+	// the offset of each letter in word
+	letters := []rune{'.'}
+	var offsets []int
+	for i, r := range word[start:end] {
+		switch {
+		case r == '’':
+			r = '\''
+		case r != '\'' && !unicode.IsLetter(r):
+			return nil
+		}
+		letters = append(letters, unicode.ToLower(r))
+		offsets = append(offsets, start+i)
+	}
+	letters = append(letters, '.')
+	n := len(offsets)
+	if n < h.left+h.right {
+		return nil
+	}
+
+	values := make([]byte, len(letters)+1)
+	for i := range letters {
+		for j := i + 1; j <= len(letters) && j-i <= h.maxLen; j++ {
+			v, ok := h.patterns[string(letters[i:j])]
+			if !ok {
+				continue
+			}
+			for k, x := range v {
+				values[i+k] = max(values[i+k], x)
+			}
+		}
+	}
+
+	// an odd value before letter k of the word (letters[k+1]) allows a
+	// hyphen there
+	var points []int
+	for k := h.left; k <= n-h.right; k++ {
+		if values[k+1]%2 == 1 {
+			points = append(points, offsets[k])
+		}
+	}
+	return points
+}
+
+// Hyphenate returns word with the given separator inserted at every
+// hyphenation point, such as "hy-phen-ation" for "hyphenation" and "-".
+func (h *Hyphenator) Hyphenate(word, sep string) string {
+	var b strings.Builder
+	prev := 0
+	for _, p := range h.Points(word) {
+		b.WriteString(word[prev:p])
+		b.WriteString(sep)
+		prev = p
+	}
+	b.WriteString(word[prev:])
+	return b.String()
+}
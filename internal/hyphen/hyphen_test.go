@@ -0,0 +1,83 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package hyphen_test
+
+import (
+	"testing"
+
+	"github.com/xogas/cowsay-go/internal/hyphen"
+)
+
+func TestHyphenate(t *testing.T) {
+	tests := []struct {
+		lang string
+		word string
+		want string
+	}{
+		{"en-us", "hyphenation", "hy-phen-a-tion"},
+		{"en-us", "algorithm", "al-go-rithm"},
+		{"en-us", "presentation", "pre-sen-ta-tion"},
+		{"en-us", "computer", "com-puter"},
+		{"en-us", "Unbelievable,", "Un-be-liev-able,"},
+		{"en-us", "(table)", "(ta-ble)"},
+		{"en-us", "cat", "cat"},
+		{"en-us", "x86", "x86"},
+		{"en", "algorithm", "al-go-rithm"},
+		{"de", "Silbentrennung", "Sil-ben-tren-nung"},
+		{"de", "Donaudampfschifffahrt", "Do-nau-dampf-schiff-fahrt"},
+		{"de-DE", "Überraschung", "Über-ra-schung"},
+		{"fr", "hyphénation", "hy-phé-na-tion"},
+		{"fr", "extraordinaire", "ex-tra-or-di-naire"},
+		{"fr_FR", "l’anticonstitutionnellement", "l’an-ti-cons-ti-tu-tion-nel-le-ment"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.lang+"/"+tc.word, func(t *testing.T) {
+			h, err := hyphen.Load(tc.lang)
+			if err != nil {
+				t.Fatalf("Load(%q) unexpected error: %v", tc.lang, err)
+			}
+			if got := h.Hyphenate(tc.word, "-"); got != tc.want {
+				t.Errorf("Hyphenate(%q) = %q, want %q", tc.word, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadUnknownLanguage(t *testing.T) {
+	if _, err := hyphen.Load("tlh"); err == nil {
+		t.Errorf("Load(%q) expected error but got none", "tlh")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	for _, lang := range hyphen.Languages() {
+		h, err := hyphen.Load(lang)
+		if err != nil {
+			t.Fatalf("Load(%q) unexpected error: %v", lang, err)
+		}
+		if h.Points("") != nil {
+			t.Errorf("Load(%q).Points(%q) is not empty", lang, "")
+		}
+	}
+}
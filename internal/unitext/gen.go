@@ -0,0 +1,330 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build ignore
+
+// gen generates tables.go from the Unicode Character Database.
+//
+// Usage:
+//
+//	go run gen.go [-ucd URL-or-directory] [-output tables.go]
+//
+// By default the data is downloaded from unicode.org. Pass a local directory
+// laid out like https://www.unicode.org/Public/<version>/ to work offline.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const unicodeVersion = "15.0.0"
+
+var (
+	ucd    = flag.String("ucd", "https://www.unicode.org/Public/"+unicodeVersion+"/", "base URL or directory of the Unicode Character Database")
+	output = flag.String("output", "tables.go", "output file")
+)
+
+// codeRange is an inclusive range of code points sharing a property value.
+type codeRange struct {
+	lo, hi rune
+	value  string
+}
+
+func main() {
+	flag.Parse()
+
+	eaw := load("ucd/EastAsianWidth.txt")
+	gc := load("ucd/extracted/DerivedGeneralCategory.txt")
+	gbp := load("ucd/auxiliary/GraphemeBreakProperty.txt")
+	emoji := load("ucd/emoji/emoji-data.txt")
+	lb := load("ucd/LineBreak.txt")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen.go from Unicode %s; DO NOT EDIT.\n\n", unicodeVersion)
+	fmt.Fprintf(&buf, "package unitext\n\n")
+	fmt.Fprintf(&buf, "import \"unicode\"\n\n")
+	fmt.Fprintf(&buf, "// UnicodeVersion is the version of the Unicode Character Database the\n")
+	fmt.Fprintf(&buf, "// tables are generated from.\n")
+	fmt.Fprintf(&buf, "const UnicodeVersion = %q\n\n", unicodeVersion)
+
+	writeTable(&buf, "wide", "East_Asian_Width W and F: two columns.",
+		filter(eaw, "W", "F"))
+	writeTable(&buf, "zeroWidth", "General_Category Mn, Me and Cf except U+00AD SOFT HYPHEN, plus\n// the Hangul Jamo vowels and trailing consonants: no columns.",
+		merge(exclude(filter(gc, "Mn", "Me", "Cf"), 0x00AD), filter(gbp, "V", "T")))
+	writeTable(&buf, "emojiPresentation", "Emoji_Presentation: shown as two-column emoji by default.",
+		filter(emoji, "Emoji_Presentation"))
+	writeTable(&buf, "extendedPictographic", "Extended_Pictographic: emoji that may be joined by ZWJ.",
+		filter(emoji, "Extended_Pictographic"))
+	writeTable(&buf, "halfwidth", "East_Asian_Width H: halfwidth forms, used by rule LB30.",
+		filter(eaw, "H"))
+	writeTable(&buf, "unassignedPictographic", "unassigned Extended_Pictographic code points,\n// used by rule LB30b.",
+		subtract(filter(emoji, "Extended_Pictographic"), exclude(gc, -1, "Cn")))
+	writeProperties(&buf, "graphemeBreakProperties", "graphemeBreak", "gb", "Grapheme_Cluster_Break", gbp)
+	writeProperties(&buf, "lineBreakProperties", "lineBreak", "lb", "Line_Break", resolveLineBreak(lb, gc))
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("format: %v", err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load reads a UCD property file and returns its ranges sorted by code point.
+func load(name string) []codeRange {
+	r, err := open(name)
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var ranges []codeRange
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		fields := strings.Split(line, ";")
+		if len(fields) < 2 {
+			continue
+		}
+		lo, hi, err := parseRange(strings.TrimSpace(fields[0]))
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		ranges = append(ranges, codeRange{lo, hi, strings.TrimSpace(fields[1])})
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].lo < ranges[j].lo })
+	return ranges
+}
+
+func open(name string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(*ucd, "http://") && !strings.HasPrefix(*ucd, "https://") {
+		return os.Open(filepath.Join(*ucd, filepath.FromSlash(name)))
+	}
+	resp, err := http.Get(strings.TrimSuffix(*ucd, "/") + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", resp.Request.URL, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func parseRange(s string) (lo, hi rune, err error) {
+	first, last, found := strings.Cut(s, "..")
+	l, err := strconv.ParseUint(first, 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	h := l
+	if found {
+		if h, err = strconv.ParseUint(last, 16, 32); err != nil {
+			return 0, 0, err
+		}
+	}
+	return rune(l), rune(h), nil
+}
+
+// filter returns the ranges having one of the given values.
+func filter(ranges []codeRange, values ...string) []codeRange {
+	var out []codeRange
+	for _, r := range ranges {
+		for _, v := range values {
+			if r.value == v {
+				out = append(out, r)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// exclude removes a single code point (unless cp is negative) and all ranges
+// having one of the given values.
+func exclude(ranges []codeRange, cp rune, values ...string) []codeRange {
+	var out []codeRange
+	for _, r := range ranges {
+		if len(filter([]codeRange{r}, values...)) > 0 {
+			continue
+		}
+		if cp < r.lo || cp > r.hi {
+			out = append(out, r)
+			continue
+		}
+		if r.lo < cp {
+			out = append(out, codeRange{r.lo, cp - 1, r.value})
+		}
+		if cp < r.hi {
+			out = append(out, codeRange{cp + 1, r.hi, r.value})
+		}
+	}
+	return out
+}
+
+// subtract removes the code points covered by b from the ranges in a.
+func subtract(a, b []codeRange) []codeRange {
+	var out []codeRange
+	for _, r := range a {
+		lo := r.lo
+		for _, x := range b {
+			if x.hi < lo || x.lo > r.hi {
+				continue
+			}
+			if x.lo > lo {
+				out = append(out, codeRange{lo, x.lo - 1, r.value})
+			}
+			lo = x.hi + 1
+		}
+		if lo <= r.hi {
+			out = append(out, codeRange{lo, r.hi, r.value})
+		}
+	}
+	return out
+}
+
+// resolveLineBreak applies rule LB1 of UAX #14 to the Line_Break classes:
+// AI, SG and XX become AL, CJ becomes NS, and SA becomes CM for combining
+// marks (General_Category Mn and Mc) and AL otherwise.
+func resolveLineBreak(lb, gc []codeRange) []codeRange {
+	marks := filter(gc, "Mn", "Mc")
+	var out []codeRange
+	for _, r := range lb {
+		switch r.value {
+		case "AI", "SG", "XX":
+			r.value = "AL"
+		case "CJ":
+			r.value = "NS"
+		case "SA":
+			for _, m := range subtract([]codeRange{r}, subtract([]codeRange{r}, marks)) {
+				out = append(out, codeRange{m.lo, m.hi, "CM"})
+			}
+			r.value = "AL"
+			out = append(out, subtract([]codeRange{r}, marks)...)
+			continue
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].lo < out[j].lo })
+	return out
+}
+
+// merge combines range lists into one sorted list.
+func merge(lists ...[]codeRange) []codeRange {
+	var out []codeRange
+	for _, l := range lists {
+		out = append(out, l...)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].lo < out[j].lo })
+	return out
+}
+
+// writeTable writes the ranges as a *unicode.RangeTable, joining adjacent
+// ranges.
+func writeTable(w io.Writer, name, doc string, ranges []codeRange) {
+	var r16, r32 []string
+	var latin int
+	for i := 0; i < len(ranges); i++ {
+		lo, hi := ranges[i].lo, ranges[i].hi
+		for i+1 < len(ranges) && ranges[i+1].lo <= hi+1 {
+			i++
+			hi = max(hi, ranges[i].hi)
+		}
+		if hi <= 0xFFFF {
+			r16 = append(r16, fmt.Sprintf("{0x%04X, 0x%04X, 1},", lo, hi))
+			if hi <= 0xFF {
+				latin++
+			}
+			continue
+		}
+		if lo <= 0xFFFF {
+			r16 = append(r16, fmt.Sprintf("{0x%04X, 0xFFFF, 1},", lo))
+			lo = 0x10000
+		}
+		r32 = append(r32, fmt.Sprintf("{0x%05X, 0x%05X, 1},", lo, hi))
+	}
+
+	fmt.Fprintf(w, "// %s lists %s\n", name, doc)
+	fmt.Fprintf(w, "var %s = &unicode.RangeTable{\n", name)
+	if len(r16) > 0 {
+		fmt.Fprintf(w, "R16: []unicode.Range16{\n%s\n},\n", strings.Join(r16, "\n"))
+	}
+	if len(r32) > 0 {
+		fmt.Fprintf(w, "R32: []unicode.Range32{\n%s\n},\n", strings.Join(r32, "\n"))
+	}
+	fmt.Fprintf(w, "LatinOffset: %d,\n}\n\n", latin)
+}
+
+// writeProperties writes an enumerated property as a type, its constants
+// and a sorted table of ranges for binary search, joining adjacent ranges
+// with the same value.
+func writeProperties(w io.Writer, name, typ, prefix, property string, ranges []codeRange) {
+	seen := map[string]bool{}
+	var values []string
+	for _, r := range ranges {
+		if !seen[r.value] {
+			seen[r.value] = true
+			values = append(values, r.value)
+		}
+	}
+	sort.Strings(values)
+
+	constName := func(v string) string {
+		return prefix + strings.ReplaceAll(v, "_", "")
+	}
+
+	fmt.Fprintf(w, "// %s is a value of the %s property.\n", typ, property)
+	fmt.Fprintf(w, "type %s uint8\n\n", typ)
+	fmt.Fprintf(w, "const (\n%sOther %s = iota\n", prefix, typ)
+	for _, v := range values {
+		fmt.Fprintf(w, "%s\n", constName(v))
+	}
+	fmt.Fprintf(w, ")\n\n")
+
+	fmt.Fprintf(w, "// %s maps code point ranges to their %s value.\n", name, typ)
+	fmt.Fprintf(w, "var %s = []struct {\nlo, hi rune\nvalue %s\n}{\n", name, typ)
+	for i := 0; i < len(ranges); i++ {
+		r := ranges[i]
+		for i+1 < len(ranges) && ranges[i+1].lo == r.hi+1 && ranges[i+1].value == r.value {
+			i++
+			r.hi = ranges[i].hi
+		}
+		fmt.Fprintf(w, "{0x%04X, 0x%04X, %s},\n", r.lo, r.hi, constName(r.value))
+	}
+	fmt.Fprintf(w, "}\n\n")
+}
@@ -0,0 +1,135 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package unitext
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemeBreakOf returns the Grapheme_Cluster_Break property of r.
+func graphemeBreakOf(r rune) graphemeBreak {
+	if r < 0x7F {
+		switch {
+		case r == '\r':
+			return gbCR
+		case r == '\n':
+			return gbLF
+		case r < 0x20:
+			return gbControl
+		}
+		return gbOther
+	}
+	i := sort.Search(len(graphemeBreakProperties), func(i int) bool {
+		return graphemeBreakProperties[i].hi >= r
+	})
+	if i < len(graphemeBreakProperties) && graphemeBreakProperties[i].lo <= r {
+		return graphemeBreakProperties[i].value
+	}
+	return gbOther
+}
+
+// isExtendedPictographic reports whether r has the Extended_Pictographic
+// property.
+func isExtendedPictographic(r rune) bool {
+	return r >= 0xA9 && unicode.Is(extendedPictographic, r)
+}
+
+// FirstGrapheme returns the first extended grapheme cluster of s, following
+// the rules of UAX #29, together with its display width in columns.
+func FirstGrapheme(s string) (cluster string, width int) {
+	if s == "" {
+		return "", 0
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	prev := graphemeBreakOf(r)
+	// pictographic is set while the cluster ends in an Extended_Pictographic
+	// rune followed by Extend runes and at most one ZWJ, so that a ZWJ may
+	// join another emoji (GB11).
+	pictographic := isExtendedPictographic(r)
+	regionalIndicators := 0
+	if prev == gbRegionalIndicator {
+		regionalIndicators = 1
+	}
+
+	end := size
+	for end < len(s) {
+		r, size = utf8.DecodeRuneInString(s[end:])
+		next := graphemeBreakOf(r)
+		if !joins(prev, next, r, pictographic, regionalIndicators) {
+			break
+		}
+
+		if next == gbRegionalIndicator {
+			regionalIndicators++
+		}
+		switch {
+		case isExtendedPictographic(r):
+			pictographic = true
+		case next == gbExtend:
+			// still in "ExtPict Extend*"
+		case next == gbZWJ && prev != gbZWJ:
+			// "ExtPict Extend* ZWJ" may be followed by another emoji
+		default:
+			pictographic = false
+		}
+
+		prev = next
+		end += size
+	}
+
+	cluster = s[:end]
+	return cluster, clusterWidth(cluster)
+}
+
+// joins reports whether there is no grapheme cluster boundary between a rune
+// with property prev and the next rune r with property next.
+func joins(prev, next graphemeBreak, r rune, pictographic bool, regionalIndicators int) bool {
+	switch {
+	case prev == gbCR && next == gbLF: // GB3
+		return true
+	case prev == gbControl || prev == gbCR || prev == gbLF: // GB4
+		return false
+	case next == gbControl || next == gbCR || next == gbLF: // GB5
+		return false
+	case prev == gbL && (next == gbL || next == gbV || next == gbLV || next == gbLVT): // GB6
+		return true
+	case (prev == gbLV || prev == gbV) && (next == gbV || next == gbT): // GB7
+		return true
+	case (prev == gbLVT || prev == gbT) && next == gbT: // GB8
+		return true
+	case next == gbExtend || next == gbZWJ: // GB9
+		return true
+	case next == gbSpacingMark: // GB9a
+		return true
+	case prev == gbPrepend: // GB9b
+		return true
+	case prev == gbZWJ && pictographic && isExtendedPictographic(r): // GB11
+		return true
+	case prev == gbRegionalIndicator && next == gbRegionalIndicator: // GB12, GB13
+		return regionalIndicators%2 == 1
+	}
+	return false // GB999
+}
@@ -0,0 +1,111 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package unitext
+
+import (
+	"bufio"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// breakTest is a test case from one of the Unicode break test files.
+type breakTest struct {
+	line  int
+	input string
+	want  []string // the expected segments
+}
+
+// readBreakTests reads a Unicode break test file such as
+// GraphemeBreakTest.txt, where ÷ marks a boundary and × marks no boundary.
+func readBreakTests(t *testing.T, name string) []breakTest {
+	t.Helper()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatalf("failed to open test data: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var tests []breakTest
+	sc := bufio.NewScanner(f)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// the fields alternate between marks and code points, starting and
+		// ending with a mark; the one at the start is × in the line break
+		// tests, where a line never breaks before its first character
+		tc := breakTest{line: lineNo}
+		var b strings.Builder
+		fields := strings.Fields(line)
+		if len(fields)%2 == 0 {
+			t.Fatalf("%s:%d: missing mark", name, lineNo)
+		}
+		for i, field := range fields {
+			if i%2 == 1 {
+				v, err := strconv.ParseUint(field, 16, 32)
+				if err != nil {
+					t.Fatalf("%s:%d: %v", name, lineNo, err)
+				}
+				b.WriteRune(rune(v))
+				continue
+			}
+			switch {
+			case field != "÷" && field != "×":
+				t.Fatalf("%s:%d: invalid mark %q", name, lineNo, field)
+			case i == len(fields)-1 && field != "÷":
+				t.Fatalf("%s:%d: no boundary at the end", name, lineNo)
+			case i > 0 && field == "÷":
+				tc.want = append(tc.want, b.String())
+				tc.input += b.String()
+				b.Reset()
+			}
+		}
+		tests = append(tests, tc)
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+	return tests
+}
+
+func TestGraphemeBreakConformance(t *testing.T) {
+	for _, tc := range readBreakTests(t, "testdata/GraphemeBreakTest.txt") {
+		var got []string
+		for s := tc.input; len(s) > 0; {
+			cluster, _ := FirstGrapheme(s)
+			got = append(got, cluster)
+			s = s[len(cluster):]
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("line %d: FirstGrapheme splits %q into %q, want %q", tc.line, tc.input, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package unitext
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LineSegment is a piece of text that ends at a line break opportunity.
+type LineSegment struct {
+	Text      string // the text, including trailing spaces
+	Mandatory bool   // the line must break after Text (a hard line break)
+}
+
+// lineBreakOf returns the Line_Break class of r, resolved by rule LB1.
+func lineBreakOf(r rune) lineBreak {
+	i := sort.Search(len(lineBreakProperties), func(i int) bool {
+		return lineBreakProperties[i].hi >= r
+	})
+	if i < len(lineBreakProperties) && lineBreakProperties[i].lo <= r {
+		return lineBreakProperties[i].value
+	}
+	return lbAL // unassigned code points are XX, which LB1 resolves to AL
+}
+
+// breakAction is the outcome of the line breaking rules at a position.
+type breakAction int
+
+const (
+	breakProhibited breakAction = iota
+	breakAllowed
+	breakMandatory
+)
+
+// lineBreaker holds the code points of a text and their classes while the
+// rules of UAX #14 are applied between them.
+type lineBreaker struct {
+	runes []rune
+	pos   []int       // byte offset of each rune
+	class []lineBreak // class after LB1
+	eff   []lineBreak // class after LB9 and LB10
+	base  []int       // index of the rune whose class eff holds
+}
+
+// LineSegments splits s into segments at its line break opportunities,
+// following the Unicode Line Breaking Algorithm (UAX #14). Wrapping text
+// means choosing some of these opportunities; the others are kept on the
+// same line. Spaces stay at the end of the segment they follow.
+func LineSegments(s string) []LineSegment {
+	if s == "" {
+		return nil
+	}
+
+	lb := newLineBreaker(s)
+	var segments []LineSegment
+	start := 0
+	for i := 1; i < len(lb.runes); i++ {
+		action := lb.action(i)
+		if action == breakProhibited {
+			continue
+		}
+		segments = append(segments, LineSegment{
+			Text:      s[start:lb.pos[i]],
+			Mandatory: action == breakMandatory,
+		})
+		start = lb.pos[i]
+	}
+
+	last := lb.class[len(lb.class)-1]
+	return append(segments, LineSegment{
+		Text:      s[start:],
+		Mandatory: last == lbBK || last == lbCR || last == lbLF || last == lbNL,
+	})
+}
+
+func newLineBreaker(s string) *lineBreaker {
+	n := utf8.RuneCountInString(s)
+	lb := &lineBreaker{
+		runes: make([]rune, 0, n),
+		pos:   make([]int, 0, n),
+		class: make([]lineBreak, 0, n),
+		eff:   make([]lineBreak, 0, n),
+		base:  make([]int, 0, n),
+	}
+
+	for pos, r := range s {
+		i := len(lb.runes)
+		c := lineBreakOf(r)
+		lb.runes = append(lb.runes, r)
+		lb.pos = append(lb.pos, pos)
+		lb.class = append(lb.class, c)
+
+		switch {
+		case (c == lbCM || c == lbZWJ) && i > 0 && !isBreakOrSpace(lb.eff[i-1]):
+			// LB9: X (CM | ZWJ)* is treated as X
+			lb.eff = append(lb.eff, lb.eff[i-1])
+			lb.base = append(lb.base, lb.base[i-1])
+		case c == lbCM || c == lbZWJ:
+			// LB10: any remaining CM or ZWJ is treated as AL
+			lb.eff = append(lb.eff, lbAL)
+			lb.base = append(lb.base, i)
+		default:
+			lb.eff = append(lb.eff, c)
+			lb.base = append(lb.base, i)
+		}
+	}
+	return lb
+}
+
+func isBreakOrSpace(c lineBreak) bool {
+	return c == lbBK || c == lbCR || c == lbLF || c == lbNL || c == lbSP || c == lbZW
+}
+
+// before returns the index of the last rune of the unit preceding the unit
+// that ends at rune i, or -1 at the start of the text.
+func (lb *lineBreaker) before(i int) int {
+	return lb.base[i] - 1
+}
+
+// action applies the rules of UAX #14 to the position between rune i-1 and
+// rune i.
+func (lb *lineBreaker) action(i int) breakAction {
+	prev, next := lb.class[i-1], lb.class[i]
+
+	// mandatory breaks and spaces
+	switch {
+	case prev == lbBK: // LB4
+		return breakMandatory
+	case prev == lbCR && next == lbLF: // LB5
+		return breakProhibited
+	case prev == lbCR || prev == lbLF || prev == lbNL: // LB5
+		return breakMandatory
+	case next == lbBK || next == lbCR || next == lbLF || next == lbNL: // LB6
+		return breakProhibited
+	case next == lbSP || next == lbZW: // LB7
+		return breakProhibited
+	}
+
+	// skip back over spaces for the "X SP* ×" rules
+	j := i - 1
+	for j >= 0 && lb.class[j] == lbSP {
+		j--
+	}
+	if j >= 0 && lb.class[j] == lbZW { // LB8
+		return breakAllowed
+	}
+	if prev == lbZWJ { // LB8a
+		return breakProhibited
+	}
+	if lb.base[i] != i { // LB9
+		return breakProhibited
+	}
+
+	a, b := lb.eff[i-1], lb.eff[i]
+	beforeSpaces := lbSP
+	if j >= 0 {
+		beforeSpaces = lb.eff[j]
+	}
+
+	switch {
+	case a == lbWJ || b == lbWJ: // LB11
+		return breakProhibited
+	case a == lbGL: // LB12
+		return breakProhibited
+	case b == lbGL && a != lbSP && a != lbBA && a != lbHY: // LB12a
+		return breakProhibited
+	case b == lbCL || b == lbCP || b == lbEX || b == lbIS || b == lbSY: // LB13
+		return breakProhibited
+	case beforeSpaces == lbOP: // LB14
+		return breakProhibited
+	case beforeSpaces == lbQU && b == lbOP: // LB15
+		return breakProhibited
+	case (beforeSpaces == lbCL || beforeSpaces == lbCP) && b == lbNS: // LB16
+		return breakProhibited
+	case beforeSpaces == lbB2 && b == lbB2: // LB17
+		return breakProhibited
+	case a == lbSP: // LB18
+		return breakAllowed
+	case a == lbQU || b == lbQU: // LB19
+		return breakProhibited
+	case a == lbCB || b == lbCB: // LB20
+		return breakAllowed
+	case b == lbBA || b == lbHY || b == lbNS || a == lbBB: // LB21
+		return breakProhibited
+	case (a == lbHY || a == lbBA) && lb.effBefore(i-1) == lbHL: // LB21a
+		return breakProhibited
+	case a == lbSY && b == lbHL: // LB21b
+		return breakProhibited
+	case b == lbIN: // LB22
+		return breakProhibited
+	case (a == lbAL || a == lbHL) && b == lbNU, a == lbNU && (b == lbAL || b == lbHL): // LB23
+		return breakProhibited
+	case a == lbPR && (b == lbID || b == lbEB || b == lbEM), (a == lbID || a == lbEB || a == lbEM) && b == lbPO: // LB23a
+		return breakProhibited
+	case (a == lbPR || a == lbPO) && (b == lbAL || b == lbHL), (a == lbAL || a == lbHL) && (b == lbPR || b == lbPO): // LB24
+		return breakProhibited
+	case lb.numeric(i, a, b): // LB25
+		return breakProhibited
+	case a == lbJL && (b == lbJL || b == lbJV || b == lbH2 || b == lbH3): // LB26
+		return breakProhibited
+	case (a == lbJV || a == lbH2) && (b == lbJV || b == lbJT), (a == lbJT || a == lbH3) && b == lbJT: // LB26
+		return breakProhibited
+	case isKorean(a) && b == lbPO, a == lbPR && isKorean(b): // LB27
+		return breakProhibited
+	case (a == lbAL || a == lbHL) && (b == lbAL || b == lbHL): // LB28
+		return breakProhibited
+	case a == lbIS && (b == lbAL || b == lbHL): // LB29
+		return breakProhibited
+	case (a == lbAL || a == lbHL || a == lbNU) && b == lbOP && !isEastAsian(lb.runes[i]): // LB30
+		return breakProhibited
+	case a == lbCP && !isEastAsian(lb.runes[lb.base[i-1]]) && (b == lbAL || b == lbHL || b == lbNU): // LB30
+		return breakProhibited
+	case a == lbRI && b == lbRI: // LB30a
+		if lb.regionalIndicatorsBefore(i)%2 == 1 {
+			return breakProhibited
+		}
+	case a == lbEB && b == lbEM: // LB30b
+		return breakProhibited
+	case b == lbEM && unicode.Is(unassignedPictographic, lb.runes[lb.base[i-1]]): // LB30b
+		return breakProhibited
+	}
+	return breakAllowed // LB31
+}
+
+// effBefore returns the class of the unit before the one ending at rune i.
+func (lb *lineBreaker) effBefore(i int) lineBreak {
+	if k := lb.before(i); k >= 0 {
+		return lb.eff[k]
+	}
+	return lbBK // start of text
+}
+
+// numeric implements LB25 with the regular expression tailoring of UAX #14
+// section 8.2, example 7, on which the conformance tests are based:
+//
+//	(PR | PO) × ( OP | HY )? NU
+//	( OP | HY ) × NU
+//	NU × (NU | SY | IS)
+//	NU (NU | SY | IS)* × (NU | SY | IS | CL | CP)
+//	NU (NU | SY | IS)* (CL | CP)? × (PR | PO)
+func (lb *lineBreaker) numeric(i int, a, b lineBreak) bool {
+	switch {
+	case (a == lbPR || a == lbPO) && b == lbNU:
+		return true
+	case (a == lbPR || a == lbPO) && (b == lbOP || b == lbHY):
+		return lb.effAfter(i) == lbNU
+	case (a == lbOP || a == lbHY) && b == lbNU:
+		return true
+	case b == lbNU || b == lbSY || b == lbIS || b == lbCL || b == lbCP:
+		return lb.followsNumber(i - 1)
+	case b == lbPR || b == lbPO:
+		k := i - 1
+		if a == lbCL || a == lbCP {
+			k = lb.before(k)
+		}
+		return k >= 0 && lb.followsNumber(k)
+	}
+	return false
+}
+
+// followsNumber reports whether the units ending at rune k match
+// NU (NU | SY | IS)*.
+func (lb *lineBreaker) followsNumber(k int) bool {
+	found := false
+	for k >= 0 {
+		c := lb.eff[k]
+		if c != lbNU && c != lbSY && c != lbIS {
+			break
+		}
+		if c == lbNU {
+			found = true
+		}
+		k = lb.before(k)
+	}
+	return found
+}
+
+// effAfter returns the class of the unit following the one starting at
+// rune i, or lbBK at the end of the text.
+func (lb *lineBreaker) effAfter(i int) lineBreak {
+	for k := i + 1; k < len(lb.runes); k++ {
+		if lb.base[k] == k {
+			return lb.eff[k]
+		}
+	}
+	return lbBK
+}
+
+// regionalIndicatorsBefore counts the regional indicators in the run of
+// units ending at rune i-1.
+func (lb *lineBreaker) regionalIndicatorsBefore(i int) int {
+	n := 0
+	for k := i - 1; k >= 0 && lb.eff[k] == lbRI; k = lb.before(k) {
+		n++
+	}
+	return n
+}
+
+func isKorean(c lineBreak) bool {
+	return c == lbJL || c == lbJV || c == lbJT || c == lbH2 || c == lbH3
+}
+
+// isEastAsian reports whether r has East_Asian_Width F, W or H.
+func isEastAsian(r rune) bool {
+	return unicode.Is(wide, r) || unicode.Is(halfwidth, r)
+}
@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package unitext
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	textPresentation    = '\uFE0E' // VARIATION SELECTOR-15
+	emojiPresentationVS = '\uFE0F' // VARIATION SELECTOR-16
+	combiningKeycap     = '\u20E3' // COMBINING ENCLOSING KEYCAP
+)
+
+// RuneWidth returns the number of columns a terminal uses for r on its own:
+// 0 for control characters, combining marks and other invisible format
+// characters, 2 for East Asian wide and fullwidth characters, and 1 for
+// everything else, including East Asian ambiguous characters.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r >= 0x7F && r < 0xA0:
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.Is(zeroWidth, r):
+		return 0
+	case unicode.Is(wide, r):
+		return 2
+	}
+	return 1
+}
+
+// clusterWidth returns the display width of a single grapheme cluster. The
+// width is that of its first visible rune, adjusted for emoji presentation
+// selectors, keycaps and flags.
+func clusterWidth(cluster string) int {
+	first, size := utf8.DecodeRuneInString(cluster)
+	if len(cluster) == size {
+		return RuneWidth(first)
+	}
+
+	width := 0
+	for _, r := range cluster {
+		if width = RuneWidth(r); width > 0 {
+			break
+		}
+	}
+
+	rest := cluster[size:]
+	switch {
+	case graphemeBreakOf(first) == gbRegionalIndicator:
+		// a pair of regional indicators is a flag
+		return 2
+	case containsRune(rest, emojiPresentationVS) || containsRune(rest, combiningKeycap):
+		return 2
+	case containsRune(rest, textPresentation) && unicode.Is(emojiPresentation, first):
+		return 1
+	}
+	return width
+}
+
+func containsRune(s string, r rune) bool {
+	for _, c := range s {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Width returns the number of columns s occupies on a terminal, measured
+// grapheme cluster by grapheme cluster.
+func Width(s string) int {
+	width := 0
+	for len(s) > 0 {
+		// fast path for ASCII not followed by a combining rune
+		if c := s[0]; c >= 0x20 && c < 0x7F && (len(s) == 1 || s[1] < utf8.RuneSelf) {
+			width++
+			s = s[1:]
+			continue
+		}
+		cluster, w := FirstGrapheme(s)
+		width += w
+		s = s[len(cluster):]
+	}
+	return width
+}
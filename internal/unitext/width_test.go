@@ -0,0 +1,102 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package unitext
+
+import (
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+	}{
+		{name: "empty", s: "", width: 0},
+		{name: "ascii", s: "Hello, World!", width: 13},
+		{name: "control characters", s: "a\x1b\x07b", width: 2},
+		{name: "precomposed latin", s: "café", width: 4},
+		{name: "decomposed latin", s: "cafe\u0301", width: 4},
+		{name: "stacked combining marks", s: "a\u0301\u0316\u0357", width: 1},
+		{name: "cyrillic", s: "Привет", width: 6},
+		{name: "greek", s: "Ωμέγα", width: 5},
+		{name: "box drawing", s: "┌─┐│└┘", width: 6},
+		{name: "han", s: "你好", width: 4},
+		{name: "hiragana and katakana", s: "ひらカタ", width: 8},
+		{name: "halfwidth katakana", s: "ｶﾀｶﾅ", width: 4},
+		{name: "fullwidth latin", s: "ＡＢＣ", width: 6},
+		{name: "hangul syllables", s: "한국어", width: 6},
+		{name: "hangul jamo sequence", s: "각", width: 2},
+		{name: "thai with combining vowel", s: "ก\u0E34", width: 1},
+		{name: "devanagari with spacing mark", s: "क\u093F", width: 1},
+		{name: "emoji", s: "😀", width: 2},
+		{name: "text presentation symbol", s: "❤", width: 1},
+		{name: "emoji presentation selector", s: "❤\uFE0F", width: 2},
+		{name: "text presentation selector", s: "⌚\uFE0E", width: 1},
+		{name: "skin tone modifier", s: "👍\U0001F3FD", width: 2},
+		{name: "zwj family", s: "👨\u200D👩\u200D👧\u200D👦", width: 2},
+		{name: "zwj profession", s: "🧑\u200D💻", width: 2},
+		{name: "flag", s: "🇯🇵", width: 2},
+		{name: "two flags", s: "🇯🇵🇫🇷", width: 4},
+		{name: "lone regional indicator", s: "🇯", width: 1},
+		{name: "keycap", s: "1\uFE0F\u20E3", width: 2},
+		{name: "zero width space and joiners", s: "a\u200Bb\u200Cc\u200Dd", width: 4},
+		{name: "byte order mark", s: "\uFEFFa", width: 1},
+		{name: "soft hyphen", s: "\u00AD", width: 1},
+		{name: "mixed", s: "héllo 世界 👋\U0001F3FB!", width: 14},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Width(tc.s); got != tc.width {
+				t.Fatalf("Width(%q) = %d, want %d", tc.s, got, tc.width)
+			}
+		})
+	}
+}
+
+func TestFirstGrapheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		cluster string
+		width   int
+	}{
+		{name: "empty", s: "", cluster: "", width: 0},
+		{name: "ascii", s: "ab", cluster: "a", width: 1},
+		{name: "crlf", s: "\r\nx", cluster: "\r\n", width: 0},
+		{name: "combining mark", s: "e\u0301x", cluster: "e\u0301", width: 1},
+		{name: "zwj sequence", s: "👩\u200D🚀!", cluster: "👩\u200D🚀", width: 2},
+		{name: "flags pair up", s: "🇯🇵🇫🇷", cluster: "🇯🇵", width: 2},
+		{name: "zwj without emoji", s: "a\u200Db", cluster: "a\u200D", width: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster, width := FirstGrapheme(tc.s)
+			if cluster != tc.cluster || width != tc.width {
+				t.Fatalf("FirstGrapheme(%q) = %q, %d, want %q, %d", tc.s, cluster, width, tc.cluster, tc.width)
+			}
+		})
+	}
+}
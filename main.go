@@ -26,9 +26,11 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	"io"
 	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -36,35 +38,97 @@ import (
 	"github.com/xogas/cowsay-go/assets"
 	"github.com/xogas/cowsay-go/cowsay"
 	"github.com/xogas/cowsay-go/decoration"
+	"github.com/xogas/cowsay-go/internal/term"
 )
 
 // Options struct for parse command line arguments
 type Options struct {
-	CowFilePath string
-	CowName     string
-	Random      bool
-	Rainbow     bool
-	Blob        bool
-	Wrap        int
-	ListCows    bool
-	Version     bool
-	Help        bool
+	CowFilePath  string
+	CowName      string
+	Random       bool
+	Think        bool
+	Borg         bool
+	Dead         bool
+	Greedy       bool
+	Paranoid     bool
+	Stoned       bool
+	Tired        bool
+	Wired        bool
+	Youthful     bool
+	Eyes         string
+	Tongue       string
+	BalloonStyle string
+	Rainbow      regionFlag
+	Freq         float64
+	Spread       float64
+	Seed         string
+	Direction    string
+	Gradient     string
+	GradientDir  string
+	Palette      string
+	PaletteMode  string
+	TextStyle    string
+	Blob         regionFlag
+	Decorate     string
+	Color        string
+	Sanitize     string
+	Format       string
+	HTMLTheme    string
+	Wrap         string
+	NoWrap       bool
+	Align        string
+	Fit          string
+	LongWords    string
+	Hyphenate    string
+	LineBreak    string
+	ListCows     bool
+	ListPals     bool
+	Version      bool
+	Help         bool
 }
 
 func (opts *Options) Usage() []byte {
 	buf := new(bytes.Buffer)
 
 	fmt.Fprintf(buf, "Usage: cowsay [options] [message]\n\n")
+	fmt.Fprintf(buf, "With no message, it is read from stdin when stdin is not a terminal.\n\n")
 	fmt.Fprintf(buf, "Options:\n")
 
 	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
 	_, _ = fmt.Fprintf(w, "  --filepath\tstring\tFolder where cow files are stored\n")
 	_, _ = fmt.Fprintf(w, "  --cow\tstring\tName of the cow\n")
 	_, _ = fmt.Fprintf(w, "  --random\t \tUse a random cow\n")
-	_, _ = fmt.Fprintf(w, "  --rainbow\t \tRainbow output\n")
-	_, _ = fmt.Fprintf(w, "  --blob\t \tBlob output\n")
-	_, _ = fmt.Fprintf(w, "  --wrap\tint\tWrap text at this column\n")
+	_, _ = fmt.Fprintf(w, "  --think\t \tThink instead of speak (default when run as cowthink)\n")
+	_, _ = fmt.Fprintf(w, "  -b -d -g -p\t \tBorg, dead, greedy or paranoid cow\n")
+	_, _ = fmt.Fprintf(w, "  -s -t -w -y\t \tStoned, tired, wired or youthful cow\n")
+	_, _ = fmt.Fprintf(w, "  --eyes\tstring\tCustom eyes, exactly 2 columns\n")
+	_, _ = fmt.Fprintf(w, "  --tongue\tstring\tCustom tongue, at most 2 columns\n")
+	_, _ = fmt.Fprintf(w, "  --balloon-style\tstring\tBalloon style (%s) or a style file\n", strings.Join(cowsay.BalloonStyleNames(), ", "))
+	_, _ = fmt.Fprintf(w, "  --rainbow[=regions]\t \tRainbow output, or only the given regions, e.g. cow or text+border\n")
+	_, _ = fmt.Fprintf(w, "  --freq\tfloat\tRainbow frequency (default %s)\n", flag.Lookup("freq").DefValue)
+	_, _ = fmt.Fprintf(w, "  --spread\tfloat\tRainbow spread (default %s)\n", flag.Lookup("spread").DefValue)
+	_, _ = fmt.Fprintf(w, "  --seed\tstring\tRainbow seed: a number, random, or hash of the message\n")
+	_, _ = fmt.Fprintf(w, "  --gradient\tstring\tGradient through hex colors, e.g. '#ff0000,#0000ff'\n")
+	_, _ = fmt.Fprintf(w, "  --gradient-direction\tstring\tGradient direction: diagonal, horizontal, vertical or radial\n")
+	_, _ = fmt.Fprintf(w, "  --palette\tstring\tColor with a palette (%s) or a palette file\n", strings.Join(decoration.PaletteNames(), ", "))
+	_, _ = fmt.Fprintf(w, "  --palette-mode\tstring\tPalette colors per line (bands) or per character (chars)\n")
+	_, _ = fmt.Fprintf(w, "  --direction\tstring\tRainbow direction: diagonal, horizontal or vertical\n")
+	_, _ = fmt.Fprintf(w, "  --style\tstring\tText attributes and colors, e.g. underline,fg=yellow,bg=blue\n")
+	_, _ = fmt.Fprintf(w, "  --blob[=regions]\t \tBlob output, or only the given regions\n")
+	_, _ = fmt.Fprintf(w, "  --decorate\tstring\tDecorators to apply in order, e.g. rainbow:freq=0.2,bold (%s)\n", strings.Join(decoration.Names(), ", "))
+	_, _ = fmt.Fprintf(w, "  --color\tstring\tUse colors: auto (on a terminal, per COLORTERM, TERM and NO_COLOR), always or never\n")
+	_, _ = fmt.Fprintf(w, "  --sanitize\tstring\tEscapes in the message: auto (sgr on a terminal, strip otherwise), sgr, strip, escape or off\n")
+	_, _ = fmt.Fprintf(w, "  --format\tstring\tOutput format: text, html (a <pre> block) or html-page\n")
+	_, _ = fmt.Fprintf(w, "  --html-theme\tstring\tColors of HTML output: dark or light\n")
+	_, _ = fmt.Fprintf(w, "  --wrap\tint|auto\tWrap text at this column, or fit the terminal width\n")
+	_, _ = fmt.Fprintf(w, "  -n\t \tDo not wrap; keep the message as is\n")
+	_, _ = fmt.Fprintf(w, "  --align\tstring\tAlign text: left, center, right or justify\n")
+	_, _ = fmt.Fprintf(w, "  --fit\tstring\tChoose line breaks: greedy, or optimal for even lines\n")
+	_, _ = fmt.Fprintf(w, "  --long-words\tstring\tWords wider than --wrap: keep, break or separators\n")
+	_, _ = fmt.Fprintf(w, "  --hyphenate\tstring\tHyphenate wrapped words in this language (%s)\n", strings.Join(cowsay.HyphenationLanguages(), ", "))
+	_, _ = fmt.Fprintf(w, "  --line-break\tstring\tWhere lines may break: unicode or spaces\n")
 	_, _ = fmt.Fprintf(w, "  --list\t \tList all available cows\n")
+	_, _ = fmt.Fprintf(w, "  --list-palettes\t \tList the built-in palettes\n")
 	_, _ = fmt.Fprintf(w, "  --version\t \tShow version information\n")
 	_, _ = fmt.Fprintf(w, "  --help\t \tShow help message\n")
 
@@ -73,23 +137,271 @@ func (opts *Options) Usage() []byte {
 	return buf.Bytes()
 }
 
+// mood returns the mood selected by the -b, -d, -g, -p, -s, -t, -w and -y
+// flags. At most one of them may be set.
+func (opts *Options) mood() (cowsay.Mood, error) {
+	selected := []struct {
+		set  bool
+		mood cowsay.Mood
+	}{
+		{opts.Borg, cowsay.MoodBorg},
+		{opts.Dead, cowsay.MoodDead},
+		{opts.Greedy, cowsay.MoodGreedy},
+		{opts.Paranoid, cowsay.MoodParanoid},
+		{opts.Stoned, cowsay.MoodStoned},
+		{opts.Tired, cowsay.MoodTired},
+		{opts.Wired, cowsay.MoodWired},
+		{opts.Youthful, cowsay.MoodYouthful},
+	}
+
+	mood := cowsay.MoodNormal
+	for _, s := range selected {
+		if !s.set {
+			continue
+		}
+		if mood != cowsay.MoodNormal {
+			return cowsay.MoodNormal, fmt.Errorf("cannot combine %s and %s moods", mood, s.mood)
+		}
+		mood = s.mood
+	}
+	return mood, nil
+}
+
+// decorators returns the decoration pipeline selected by --decorate, with
+// colors limited to what --color and the terminal allow, or in full for
+// HTML output. --rainbow, --gradient, --palette, --style and --blob are
+// shorthands that run before it, in that order. msg is the message, which
+// --seed=hash derives the rainbow colors from.
+func (opts *Options) decorators(msg string) (decoration.Pipeline, error) {
+	mode, err := decoration.ParseColorMode(opts.Color)
+	if err != nil {
+		return nil, err
+	}
+	profile := mode.Profile(os.Getenv, term.IsTerminal(os.Stdout))
+	if opts.Format != "text" && mode != decoration.ColorNever {
+		profile = decoration.ProfileTrueColor
+	}
+
+	var specs []string
+	if opts.Rainbow.on {
+		specs = append(specs, opts.rainbowSpec(msg)+opts.Rainbow.param())
+	}
+	pipeline, err := decoration.Parse(strings.Join(specs, ","), profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// the gradient, palette and style are built directly, since their
+	// values, such as a palette file path, may not fit in a spec
+	if opts.Gradient != "" {
+		d, err := gradientDecorator(opts.Gradient, opts.GradientDir, profile)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, d)
+	}
+	if opts.Palette != "" {
+		d, err := paletteDecorator(opts.Palette, opts.PaletteMode, profile)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, d)
+	}
+	if opts.TextStyle != "" {
+		style, err := decoration.ParseStyle(opts.TextStyle)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, decoration.NewStyle(style, profile))
+	}
+
+	specs = specs[:0]
+	if opts.Blob.on {
+		specs = append(specs, "blob"+opts.Blob.param())
+	}
+	if opts.Decorate != "" {
+		specs = append(specs, opts.Decorate)
+	}
+	rest, err := decoration.Parse(strings.Join(specs, ","), profile)
+	if err != nil {
+		return nil, err
+	}
+	return append(pipeline, rest...), nil
+}
+
+// gradientDecorator returns the decorator for --gradient, whose stops are
+// hex colors separated by commas, in the direction dir.
+func gradientDecorator(stops, dir string, profile decoration.Profile) (decoration.Decorator, error) {
+	d, err := decoration.ParseDirection(dir)
+	if err != nil {
+		return nil, err
+	}
+	opts := decoration.GradientOptions{Direction: d}
+	for hex := range strings.SplitSeq(stops, ",") {
+		c, err := decoration.ParseHexColor(strings.TrimSpace(hex))
+		if err != nil {
+			return nil, err
+		}
+		opts.Stops = append(opts.Stops, c)
+	}
+	return decoration.NewGradient(opts, profile)
+}
+
+// paletteDecorator returns the decorator for --palette, which names a
+// built-in palette or a palette file.
+func paletteDecorator(name, mode string, profile decoration.Profile) (decoration.Decorator, error) {
+	m, err := decoration.ParsePaletteMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decoration.ParsePalette(name)
+	if err != nil {
+		if _, statErr := os.Stat(name); statErr != nil {
+			return nil, err
+		}
+		if p, err = decoration.LoadPalette(name); err != nil {
+			return nil, err
+		}
+	}
+	return decoration.NewPaletteDecorator(p, m, profile)
+}
+
+// rainbowSpec returns the decorator spec for --rainbow, tuned by the
+// --freq, --spread, --seed and --direction flags. A hash seed is taken
+// from msg rather than from the rendered cow, so that the message keeps
+// its colors whatever cow and wrapping it is drawn with.
+func (opts *Options) rainbowSpec(msg string) string {
+	spec := "rainbow:freq=" + strconv.FormatFloat(opts.Freq, 'g', -1, 64) +
+		":spread=" + strconv.FormatFloat(opts.Spread, 'g', -1, 64) +
+		":direction=" + opts.Direction
+	switch opts.Seed {
+	case "":
+	case "hash":
+		spec += ":seed=" + strconv.FormatFloat(decoration.HashSeed(msg, opts.Freq), 'g', -1, 64)
+	default:
+		spec += ":seed=" + opts.Seed
+	}
+	return spec
+}
+
+// regionFlag is a boolean flag that may also name the regions of the cow
+// it applies to, as in --rainbow=cow or --blob=text+border.
+type regionFlag struct {
+	on      bool
+	regions string
+}
+
+func (f *regionFlag) String() string {
+	if f.regions != "" {
+		return f.regions
+	}
+	return strconv.FormatBool(f.on)
+}
+
+func (f *regionFlag) Set(value string) error {
+	if on, err := strconv.ParseBool(value); err == nil {
+		f.on, f.regions = on, ""
+		return nil
+	}
+	f.on, f.regions = true, strings.ReplaceAll(value, ",", "+")
+	return nil
+}
+
+func (f *regionFlag) IsBoolFlag() bool { return true }
+
+// param returns the region parameter of the decorator the flag enables.
+func (f *regionFlag) param() string {
+	if f.regions == "" {
+		return ""
+	}
+	return ":region=" + f.regions
+}
+
 var opts Options
 
 func init() {
 	flag.StringVar(&opts.CowFilePath, "filepath", "", "Folder where cow files are stored")
 	flag.StringVar(&opts.CowName, "cow", "default", "Name of the cow")
 	flag.BoolVar(&opts.Random, "random", false, "Use a random cow")
-	flag.BoolVar(&opts.Rainbow, "rainbow", false, "Rainbow output")
-	flag.BoolVar(&opts.Blob, "blob", false, "Blob output")
-	flag.IntVar(&opts.Wrap, "wrap", 40, "Wrap text at this column")
+	flag.BoolVar(&opts.Think, "think", invokedAsCowthink(), "Think instead of speak")
+	flag.BoolVar(&opts.Borg, "b", false, "Borg mode")
+	flag.BoolVar(&opts.Dead, "d", false, "Dead mode")
+	flag.BoolVar(&opts.Greedy, "g", false, "Greedy mode")
+	flag.BoolVar(&opts.Paranoid, "p", false, "Paranoid mode")
+	flag.BoolVar(&opts.Stoned, "s", false, "Stoned mode")
+	flag.BoolVar(&opts.Tired, "t", false, "Tired mode")
+	flag.BoolVar(&opts.Wired, "w", false, "Wired mode")
+	flag.BoolVar(&opts.Youthful, "y", false, "Youthful mode")
+	flag.StringVar(&opts.Eyes, "eyes", "", "Custom eyes")
+	flag.StringVar(&opts.Tongue, "tongue", "", "Custom tongue")
+	flag.StringVar(&opts.BalloonStyle, "balloon-style", "ascii", "Balloon style name or style file")
+	flag.Var(&opts.Rainbow, "rainbow", "Rainbow output, or only the given regions")
+	flag.Float64Var(&opts.Freq, "freq", 0.35, "Rainbow frequency")
+	flag.Float64Var(&opts.Spread, "spread", 1, "Rainbow spread")
+	flag.StringVar(&opts.Seed, "seed", "", "Rainbow seed: a number, random, or hash of the message")
+	flag.StringVar(&opts.Gradient, "gradient", "", "Gradient through hex colors, e.g. '#ff0000,#0000ff'")
+	flag.StringVar(&opts.GradientDir, "gradient-direction", "diagonal", "Gradient direction: diagonal, horizontal, vertical or radial")
+	flag.StringVar(&opts.Palette, "palette", "", "Color with a built-in palette or a palette file")
+	flag.StringVar(&opts.PaletteMode, "palette-mode", "bands", "Palette colors per line (bands) or per character (chars)")
+	flag.StringVar(&opts.Direction, "direction", "diagonal", "Rainbow direction: diagonal, horizontal or vertical")
+	flag.StringVar(&opts.TextStyle, "style", "", "Text attributes and colors, e.g. underline,fg=yellow,bg=blue")
+	flag.Var(&opts.Blob, "blob", "Blob output, or only the given regions")
+	flag.StringVar(&opts.Decorate, "decorate", "", "Decorators to apply in order")
+	flag.StringVar(&opts.Color, "color", "auto", "Use colors: auto, always or never")
+	flag.StringVar(&opts.Sanitize, "sanitize", "auto", "Escapes in the message: auto, sgr, strip, escape or off")
+	flag.StringVar(&opts.Format, "format", "text", "Output format: text, html or html-page")
+	flag.StringVar(&opts.HTMLTheme, "html-theme", "dark", "Colors of HTML output: dark or light")
+	flag.StringVar(&opts.Wrap, "wrap", "40", "Wrap text at this column, or auto to fit the terminal width")
+	flag.BoolVar(&opts.NoWrap, "n", false, "Do not wrap text")
+	flag.StringVar(&opts.Align, "align", "left", "Align text: left, center, right or justify")
+	flag.StringVar(&opts.Fit, "fit", "greedy", "Choose line breaks: greedy, or optimal for even lines")
+	flag.StringVar(&opts.LongWords, "long-words", "keep", "Words wider than --wrap: keep, break or separators")
+	flag.StringVar(&opts.Hyphenate, "hyphenate", "", "Hyphenate wrapped words in this language")
+	flag.StringVar(&opts.LineBreak, "line-break", "unicode", "Where lines may break: unicode or spaces")
 	flag.BoolVar(&opts.ListCows, "list", false, "List all available cows")
+	flag.BoolVar(&opts.ListPals, "list-palettes", false, "List the built-in palettes")
 	flag.BoolVar(&opts.Version, "version", false, "Show version information")
 	flag.BoolVar(&opts.Help, "help", false, "Show help message")
 }
 
+// invokedAsCowthink reports whether the binary was started as "cowthink",
+// e.g. through a symlink.
+func invokedAsCowthink() bool {
+	name := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	return name == "cowthink"
+}
+
+// tuningFlags pairs the flags that only tune another flag with it.
+var tuningFlags = [][2]string{
+	{"freq", "rainbow"},
+	{"spread", "rainbow"},
+	{"seed", "rainbow"},
+	{"direction", "rainbow"},
+	{"gradient-direction", "gradient"},
+	{"palette-mode", "palette"},
+}
+
+// checkTuningFlags fails if a flag that tunes another one is set without
+// it, as that would be ignored. set holds the names of the flags given.
+func checkTuningFlags(set map[string]bool) error {
+	for _, pair := range tuningFlags {
+		if set[pair[0]] && !set[pair[1]] {
+			return fmt.Errorf("--%s needs --%s", pair[0], pair[1])
+		}
+	}
+	return nil
+}
+
 func main() {
 	flag.Parse()
 
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if err := checkTuningFlags(set); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if opts.Help {
 		_, _ = os.Stdout.Write(opts.Usage())
 		os.Exit(0)
@@ -110,7 +422,22 @@ func main() {
 		os.Exit(0)
 	}
 
+	if opts.ListPals {
+		for _, name := range decoration.PaletteNames() {
+			_, _ = fmt.Fprintln(os.Stdout, name)
+		}
+		os.Exit(0)
+	}
+
 	msg := strings.Join(flag.Args(), " ")
+	if flag.NArg() == 0 && stdinIsPiped() {
+		in, err := readMessage(os.Stdin, maxStdinBytes)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		msg = in
+	}
 	if strings.TrimSpace(msg) == "" {
 		msg = "Hello, World!"
 	}
@@ -118,6 +445,32 @@ func main() {
 	os.Exit(run(msg))
 }
 
+// maxStdinBytes caps how much of a message is read from stdin.
+const maxStdinBytes = 1 << 20
+
+// stdinIsPiped reports whether stdin is a pipe or a redirected file rather
+// than a terminal.
+func stdinIsPiped() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
+
+// readMessage reads the whole message from r, keeping its line structure
+// but dropping trailing newlines. It fails if r holds more than limit bytes.
+func readMessage(r io.Reader, limit int64) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return "", fmt.Errorf("message on stdin exceeds %d bytes", limit)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
 func listCows() ([]byte, error) {
 	var buf bytes.Buffer
 	if opts.CowFilePath == "" {
@@ -166,22 +519,103 @@ func run(msg string) int {
 		opts.CowName = cowName
 	}
 
+	htmlOpts, html, err := opts.html()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	decorators, err := opts.decorators(msg)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
 	out, err := renderCow(msg, location, basePath)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 1
 	}
 
-	if opts.Rainbow {
-		out = decoration.Rainbow(out)
+	result := decoration.DecorateGrid(out, decorators).Bytes()
+	if html {
+		result = decoration.HTML(result, htmlOpts)
 	}
+	_, _ = os.Stdout.Write(result)
+	return 0
+}
 
-	if opts.Blob {
-		out = decoration.Blob(out)
+// html returns how --format and --html-theme convert the output to HTML,
+// and whether they do.
+func (opts *Options) html() (decoration.HTMLOptions, bool, error) {
+	switch opts.Format {
+	case "text":
+		return decoration.HTMLOptions{}, false, nil
+	case "html", "html-page":
+	default:
+		return decoration.HTMLOptions{}, false, fmt.Errorf("unknown format %q", opts.Format)
+	}
+	theme, err := decoration.ParseHTMLTheme(opts.HTMLTheme)
+	if err != nil {
+		return decoration.HTMLOptions{}, false, err
 	}
+	return decoration.HTMLOptions{Theme: theme, Page: opts.Format == "html-page"}, true, nil
+}
 
-	_, _ = os.Stdout.Write(out)
-	return 0
+// balloonStyle returns the built-in balloon style with the given name, or
+// loads it from a style file.
+func balloonStyle(name string) (cowsay.BalloonStyle, error) {
+	if style, err := cowsay.ParseBalloonStyle(name); err == nil {
+		return style, nil
+	}
+	if _, err := os.Stat(name); err != nil {
+		return cowsay.BalloonStyle{}, fmt.Errorf("unknown balloon style %q", name)
+	}
+	return cowsay.LoadBalloonStyle(name)
+}
+
+// sanitizePolicy returns the policy selected by --sanitize. "auto" keeps
+// colors in the message on a terminal or in HTML output, and strips every
+// escape when the output goes elsewhere, such as a file or a chat.
+func sanitizePolicy(name string, html bool) (cowsay.SanitizePolicy, error) {
+	if name != "auto" {
+		return cowsay.ParseSanitizePolicy(name)
+	}
+	if html || term.IsTerminal(os.Stdout) {
+		return cowsay.SanitizeSGR, nil
+	}
+	return cowsay.SanitizeStrip, nil
+}
+
+// setWrap applies the --wrap flag to the cow. "auto" fits the wrap width
+// to the terminal, leaving room for the cow art.
+func setWrap(c *cowsay.Cow, wrap string) error {
+	if wrap != "auto" {
+		n, err := strconv.Atoi(wrap)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid wrap width %q", wrap)
+		}
+		c.Wrap = n
+		return nil
+	}
+
+	columns, ok := terminalColumns()
+	if !ok {
+		// keep the default width
+		return nil
+	}
+	return c.FitWidth(columns)
+}
+
+// terminalColumns returns the width of the terminal on stdout, falling back
+// to $COLUMNS when stdout is not a terminal.
+func terminalColumns() (int, bool) {
+	if columns, ok := term.Columns(os.Stdout); ok {
+		return columns, true
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns, true
+	}
+	return 0, false
 }
 
 func determineLocationAndBase() (cowsay.LocationType, string) {
@@ -208,17 +642,66 @@ func determineLocationAndBase() (cowsay.LocationType, string) {
 	return location, basePath
 }
 
-func renderCow(msg string, location cowsay.LocationType, basePath string) ([]byte, error) {
+func renderCow(msg string, location cowsay.LocationType, basePath string) (cowsay.Grid, error) {
 	cowName := opts.CowName
 	// if user provided a specific .cow file and left default name, use file basename
 	if strings.HasSuffix(strings.ToLower(basePath), ".cow") && cowName == "default" {
 		cowName = strings.TrimSuffix(filepath.Base(basePath), ".cow")
 	}
 
+	mood, err := opts.mood()
+	if err != nil {
+		return nil, err
+	}
+	align, err := cowsay.ParseAlignment(opts.Align)
+	if err != nil {
+		return nil, err
+	}
+	fitting, err := cowsay.ParseLineFitting(opts.Fit)
+	if err != nil {
+		return nil, err
+	}
+	longWords, err := cowsay.ParseLongWordPolicy(opts.LongWords)
+	if err != nil {
+		return nil, err
+	}
+	lineBreak, err := cowsay.ParseLineBreaking(opts.LineBreak)
+	if err != nil {
+		return nil, err
+	}
+	style, err := balloonStyle(opts.BalloonStyle)
+	if err != nil {
+		return nil, err
+	}
+	sanitize, err := sanitizePolicy(opts.Sanitize, opts.Format != "text")
+	if err != nil {
+		return nil, err
+	}
+
 	c := cowsay.NewCow(cowName, basePath, location)
-	c.Wrap = opts.Wrap
+	if opts.NoWrap {
+		c.WrapMode = cowsay.WrapNone
+	}
+	c.Align = align
+	c.Fitting = fitting
+	c.LineBreak = lineBreak
+	c.LongWords = longWords
+	c.Hyphenate = opts.Hyphenate
+	c.Think = opts.Think
+	c.Style = style
+	c.Sanitize = sanitize
+	c.SetMood(mood)
+	if opts.Eyes != "" {
+		c.Eyes = opts.Eyes
+	}
+	if opts.Tongue != "" {
+		c.Tongue = opts.Tongue
+	}
+	if err := setWrap(c, opts.Wrap); err != nil {
+		return nil, err
+	}
 
-	out, err := c.Render(msg)
+	out, err := c.RenderGrid(msg)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,159 @@
+// MIT License
+//
+// Copyright (c) 2025 xogas <57179186+xogas@users.noreply.github.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xogas/cowsay-go/decoration"
+)
+
+func TestReadMessage(t *testing.T) {
+	const limit = 8
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		fail  bool
+	}{
+		{"short", "moo", "moo", false},
+		{"at limit", "12345678", "12345678", false},
+		{"over limit", "123456789", "", true},
+		{"trailing newlines", "moo\r\n\n", "moo", false},
+		{"inner blank lines", "a\n\nb\n", "a\n\nb", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readMessage(strings.NewReader(tc.input), limit)
+			if tc.fail {
+				if err == nil {
+					t.Fatalf("readMessage(%q) = %q, want an error", tc.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readMessage(%q) failed: %v", tc.input, err)
+			}
+			if got != tc.want {
+				t.Fatalf("readMessage(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecoratorsColorNever(t *testing.T) {
+	const msg = "x y\n"
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"style", Options{TextStyle: "bold,underline,fg=red"}},
+		{"blob", Options{Blob: regionFlag{on: true}}},
+		{"blob regions", Options{Blob: regionFlag{on: true, regions: "text"}}},
+		{"decorate", Options{Decorate: "bold,style:attrs=italic"}},
+		{"rainbow", Options{Rainbow: regionFlag{on: true}, Freq: 0.35, Spread: 1, Direction: "diagonal"}},
+		{"gradient", Options{Gradient: "#ff0000,#0000ff", GradientDir: "diagonal"}},
+		{"html", Options{TextStyle: "bold", Format: "html"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := tc.opts
+			opts.Color = "never"
+			if opts.Format == "" {
+				opts.Format = "text"
+			}
+			p, err := opts.decorators(msg)
+			if err != nil {
+				t.Fatalf("decorators() failed: %v", err)
+			}
+			if got := p.Decorate([]byte(msg)); string(got) != msg {
+				t.Fatalf("decorators().Decorate(%q) = %q, want it unchanged", msg, got)
+			}
+		})
+	}
+}
+
+func TestGradientDecorator(t *testing.T) {
+	tests := []struct {
+		stops string
+		dir   string
+		fail  bool
+	}{
+		{"#ff0000,#0000ff", "diagonal", false},
+		{"#ff0000, #00ff00, #0000ff", "radial", false},
+		{"#ff0000", "diagonal", true},
+		{"#ff0000-#0000ff", "diagonal", true},
+		{"#ff0000,#0000ff:direction=radial", "diagonal", true},
+		{"#ff0000,#0000ff", "sideways", true},
+	}
+
+	for _, tc := range tests {
+		_, err := gradientDecorator(tc.stops, tc.dir, decoration.ProfileTrueColor)
+		if (err != nil) != tc.fail {
+			t.Errorf("gradientDecorator(%q, %q) error = %v, want failure %t", tc.stops, tc.dir, err, tc.fail)
+		}
+	}
+}
+
+func TestRainbowSpecHashSeed(t *testing.T) {
+	opts := Options{Freq: 0.35, Spread: 1, Direction: "diagonal", Seed: "hash"}
+	moo := opts.rainbowSpec("moo")
+	if again := opts.rainbowSpec("moo"); again != moo {
+		t.Fatalf("rainbowSpec(%q) = %q and then %q", "moo", moo, again)
+	}
+	if baa := opts.rainbowSpec("baa"); baa == moo {
+		t.Fatalf("rainbowSpec(%q) = rainbowSpec(%q) = %q", "moo", "baa", moo)
+	}
+}
+
+func TestCheckTuningFlags(t *testing.T) {
+	tests := []struct {
+		flags   []string
+		wantErr string
+	}{
+		{nil, ""},
+		{[]string{"rainbow", "freq", "spread", "seed", "direction"}, ""},
+		{[]string{"freq"}, "--freq needs --rainbow"},
+		{[]string{"gradient", "direction"}, "--direction needs --rainbow"},
+		{[]string{"gradient", "gradient-direction"}, ""},
+		{[]string{"palette-mode"}, "--palette-mode needs --palette"},
+	}
+
+	for _, tc := range tests {
+		set := make(map[string]bool)
+		for _, name := range tc.flags {
+			set[name] = true
+		}
+		var got string
+		if err := checkTuningFlags(set); err != nil {
+			got = err.Error()
+		}
+		if got != tc.wantErr {
+			t.Errorf("checkTuningFlags(%q) = %q, want %q", tc.flags, got, tc.wantErr)
+		}
+	}
+}